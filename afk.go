@@ -0,0 +1,241 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 💤 AFK
+// .afk [reason] marks the sender away for this bot (all groups). Mentions of,
+// or replies to, an AFK member get a short note (once per chat per
+// afkNoticeEvery); their next message clears AFK and reports missed mentions.
+// Stored in the Redis hash afk:<bot> (cleanID -> JSON), mirrored in RAM since
+// every group message is checked. PN and LID both point to the same entry.
+
+const afkNoticeEvery = 2 * time.Minute
+
+type AFKEntry struct {
+	JID      string   `json:"jid"`
+	IDs      []string `json:"ids"`
+	Reason   string   `json:"reason,omitempty"`
+	Since    int64    `json:"since"`
+	Mentions int      `json:"mentions"`
+}
+
+var (
+	afkCache  = make(map[string]map[string]*AFKEntry) // bot -> cleanID -> entry
+	afkLoaded = make(map[string]bool)
+	afkMutex  sync.Mutex
+)
+
+func afkKey(botID string) string { return "afk:" + botID }
+
+// afkUsers بوٹ کی AFK لسٹ (پہلی بار Redis سے لوڈ)؛ کال کرنے والا لاک رکھے
+func afkUsers(botID string) map[string]*AFKEntry {
+	if afkLoaded[botID] {
+		return afkCache[botID]
+	}
+	users := make(map[string]*AFKEntry)
+	if rdb != nil {
+		all, _ := rdb.HGetAll(ctx, afkKey(botID)).Result()
+		for id, raw := range all {
+			var e AFKEntry
+			if json.Unmarshal([]byte(raw), &e) == nil {
+				users[id] = &e
+			}
+		}
+	}
+	// ایک ہی بندے کی PN/LID ایک ہی پوائنٹر پر
+	for _, e := range users {
+		for _, id := range e.IDs {
+			users[id] = e
+		}
+	}
+	afkCache[botID] = users
+	afkLoaded[botID] = true
+	return users
+}
+
+func saveAFKEntry(botID string, e *AFKEntry) {
+	if rdb == nil {
+		return
+	}
+	data, _ := json.Marshal(e)
+	for _, id := range e.IDs {
+		rdb.HSet(ctx, afkKey(botID), id, data)
+	}
+}
+
+func senderIDs(v *events.Message) []string {
+	ids := []string{getCleanID(v.Info.Sender.User)}
+	if !v.Info.SenderAlt.IsEmpty() {
+		ids = append(ids, getCleanID(v.Info.SenderAlt.User))
+	}
+	return ids
+}
+
+// afkDuration "2h 5m" / "1d 3h" / "4m"
+func afkDuration(since int64) string {
+	d := time.Since(time.Unix(since, 0))
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+	return "just now"
+}
+
+func sendAFKText(client *whatsmeow.Client, v *events.Message, text string, mentions []string) error {
+	_, err := client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text: proto.String(text),
+			ContextInfo: &waProto.ContextInfo{
+				StanzaID:      proto.String(v.Info.ID),
+				Participant:   proto.String(v.Info.Sender.String()),
+				QuotedMessage: v.Message,
+				MentionedJID:  mentions,
+			},
+		},
+	})
+	return err
+}
+
+// handleAFK ہر میسج پر: بھیجنے والا واپس آیا؟ کسی AFK کو مینشن/ریپلائی کیا؟
+func handleAFK(client *whatsmeow.Client, v *events.Message) {
+	if v.Info.IsFromMe {
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+
+	afkMutex.Lock()
+	users := afkUsers(botID)
+	if len(users) == 0 {
+		afkMutex.Unlock()
+		return
+	}
+
+	// 1️⃣ واپسی: .afk خود AFK ختم نہیں کرتا
+	var back *AFKEntry
+	body := strings.TrimSpace(getText(v.Message))
+	if !strings.HasPrefix(body, getPrefix(botID)+"afk") {
+		for _, id := range senderIDs(v) {
+			if e, ok := users[id]; ok {
+				back = e
+				break
+			}
+		}
+		if back != nil {
+			for _, id := range back.IDs {
+				delete(users, id)
+			}
+		}
+	}
+
+	// 2️⃣ مینشن یا ریپلائی والے AFK ممبرز
+	var away []*AFKEntry
+	if v.Info.IsGroup {
+		var targets []string
+		if ci := getContextInfo(v.Message); ci != nil {
+			targets = append(targets, ci.GetMentionedJID()...)
+			if ci.GetParticipant() != "" {
+				targets = append(targets, ci.GetParticipant())
+			}
+		}
+		seen := make(map[*AFKEntry]bool)
+		for _, t := range targets {
+			e, ok := users[getCleanID(t)]
+			if !ok || seen[e] || e == back {
+				continue
+			}
+			seen[e] = true
+			e.Mentions++
+			saveAFKEntry(botID, e)
+			away = append(away, e)
+		}
+	}
+	afkMutex.Unlock()
+
+	if back != nil && rdb != nil {
+		rdb.HDel(ctx, afkKey(botID), back.IDs...)
+	}
+	// بوٹ بند (private) گروپ میں خاموشی، صرف گنتی
+	if v.Info.IsGroup && getGroupSettings(botID, v.Info.Chat.String()).Mode == "private" {
+		return
+	}
+
+	if back != nil {
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 👋 WELCOME BACK
+╠════════════════╣
+║ 👤 @%s
+║ ⏱️ Away for %s
+║ 📨 %d mention(s) missed
+╚════════════════╝`, v.Info.Sender.User, afkDuration(back.Since), back.Mentions)
+		sendAFKText(client, v, msg, []string{v.Info.Sender.ToNonAD().String()})
+	}
+
+	for _, e := range away {
+		if groupCooldownLeft(v.Info.Chat, "afk:"+e.IDs[0]) > 0 {
+			continue
+		}
+		jid, _ := types.ParseJID(e.JID)
+		note := fmt.Sprintf("💤 @%s is AFK since %s", jid.User, afkDuration(e.Since))
+		if e.Reason != "" {
+			note += ": " + e.Reason
+		}
+		if sendAFKText(client, v, note, []string{e.JID}) == nil {
+			markGroupCooldown(v.Info.Chat, "afk:"+e.IDs[0], afkNoticeEvery)
+		}
+	}
+}
+
+// ---------------------------------------------------------
+// 💤 COMMAND: .afk [reason]
+// ---------------------------------------------------------
+func handleAFKCmd(client *whatsmeow.Client, v *events.Message) {
+	botID := getCleanID(client.Store.ID.User)
+	reason := getCommandBody(v)
+	if r := []rune(reason); len(r) > 200 {
+		reason = string(r[:200])
+	}
+
+	e := &AFKEntry{
+		JID:    v.Info.Sender.ToNonAD().String(),
+		IDs:    senderIDs(v),
+		Reason: reason,
+		Since:  time.Now().Unix(),
+	}
+
+	afkMutex.Lock()
+	users := afkUsers(botID)
+	for _, id := range e.IDs {
+		users[id] = e
+	}
+	afkMutex.Unlock()
+	saveAFKEntry(botID, e)
+
+	if reason == "" {
+		reason = "No reason"
+	}
+	sendAFKText(client, v, fmt.Sprintf(`╔════════════════╗
+║ 💤 AFK
+╠════════════════╣
+║ 👤 @%s
+║ 📝 %s
+║ Send any message
+║ to come back
+╚════════════════╝`, v.Info.Sender.User, reason), []string{e.JID})
+}
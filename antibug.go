@@ -0,0 +1,443 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 🛡️ ANTI-BUG ANALYZER
+// Crash texts are scored instead of matched against a fixed character list.
+// Every check adds points, and a message is treated as a bug once the total
+// reaches the configured threshold. Used for both DMs and groups.
+
+type AntiBugConfig struct {
+	Threshold     int    `json:"threshold"`       // total score that marks a message as a bug
+	MaxLength     int    `json:"max_length"`      // characters (runes) across all text fields
+	MaxBytes      int    `json:"max_bytes"`       // encoded protobuf size
+	MaxCombining  int    `json:"max_combining"`   // combining marks stacked on one character
+	MaxBidi       int    `json:"max_bidi"`        // bidi control characters
+	MaxBidiDepth  int    `json:"max_bidi_depth"`  // unclosed embedding/override/isolate depth
+	MaxInvisible  int    `json:"max_invisible"`   // zero-width and filler characters
+	MaxMentions   int    `json:"max_mentions"`    // MentionedJID entries in one message
+	MaxQuoteDepth int    `json:"max_quote_depth"` // nested quoted messages
+	Action        string `json:"action"`          // revoke | ignore
+}
+
+// BugReport ایک میسج کا تجزیہ
+type BugReport struct {
+	Score   int
+	Reasons []string
+}
+
+func (r *BugReport) add(points int, reason string) {
+	r.Score += points
+	r.Reasons = append(r.Reasons, fmt.Sprintf("%s (+%d)", reason, points))
+}
+
+const antiBugConfigKey = "antibug:config"
+
+var (
+	antiBugConfig = defaultAntiBugConfig()
+	antiBugMutex  sync.RWMutex
+)
+
+func defaultAntiBugConfig() AntiBugConfig {
+	return AntiBugConfig{
+		Threshold:     60,
+		MaxLength:     6000,
+		MaxBytes:      64 * 1024,
+		MaxCombining:  8,
+		MaxBidi:       12,
+		MaxBidiDepth:  8,
+		MaxInvisible:  40,
+		MaxMentions:   300,
+		MaxQuoteDepth: 3,
+		Action:        "revoke",
+	}
+}
+
+func getAntiBugConfig() AntiBugConfig {
+	antiBugMutex.RLock()
+	defer antiBugMutex.RUnlock()
+	return antiBugConfig
+}
+
+// ✅ Load Anti-Bug thresholds from Redis (defaults if missing)
+func loadAntiBugConfig() {
+	if rdb == nil {
+		return
+	}
+	val, err := rdb.Get(ctx, antiBugConfigKey).Result()
+	if err != nil {
+		return
+	}
+	cfg := defaultAntiBugConfig()
+	if json.Unmarshal([]byte(val), &cfg) == nil {
+		antiBugMutex.Lock()
+		antiBugConfig = cfg
+		antiBugMutex.Unlock()
+		fmt.Println("✅ [ANTIBUG] Thresholds restored from Redis")
+	}
+}
+
+func saveAntiBugConfig(cfg AntiBugConfig) {
+	antiBugMutex.Lock()
+	antiBugConfig = cfg
+	antiBugMutex.Unlock()
+	if rdb != nil {
+		jsonBytes, _ := json.Marshal(cfg)
+		rdb.Set(ctx, antiBugConfigKey, jsonBytes, 0)
+	}
+}
+
+func isBidiControl(r rune) bool {
+	switch {
+	case r >= '\u202a' && r <= '\u202e':
+		return true
+	case r >= '\u2066' && r <= '\u2069':
+		return true
+	case r == '\u200e' || r == '\u200f' || r == '\u061c':
+		return true
+	}
+	return false
+}
+
+func isInvisibleRune(r rune) bool {
+	switch {
+	case r >= '\u200b' && r <= '\u200d':
+		return true
+	case r >= '\u2060' && r <= '\u2064':
+		return true
+	case r == '\ufeff', r == '\u034f', r == '\u180e', r == '\u00ad':
+		return true
+	case r == '\u115f', r == '\u1160', r == '\u3164', r == '\uffa0':
+		return true
+	}
+	return false
+}
+
+func isCombiningRune(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me)
+}
+
+// 🔍 Text scoring (length, combining floods, bidi stacks, invisible junk)
+func analyzeBugText(text string, cfg AntiBugConfig, r *BugReport) {
+	if text == "" {
+		return
+	}
+
+	if !utf8.ValidString(text) {
+		r.add(40, "invalid UTF-8")
+	}
+
+	length := utf8.RuneCountInString(text)
+	if cfg.MaxLength > 0 && length > cfg.MaxLength {
+		points := 30
+		if length > cfg.MaxLength*4 {
+			points = 60
+		}
+		r.add(points, fmt.Sprintf("length %d", length))
+	}
+
+	combiningRun, maxCombiningRun, combiningTotal := 0, 0, 0
+	bidiTotal, invisibleTotal := 0, 0
+	depth, maxDepth := 0, 0
+
+	for _, c := range text {
+		if isCombiningRune(c) {
+			combiningRun++
+			combiningTotal++
+			if combiningRun > maxCombiningRun {
+				maxCombiningRun = combiningRun
+			}
+		} else {
+			combiningRun = 0
+		}
+
+		if isInvisibleRune(c) {
+			invisibleTotal++
+		}
+
+		if isBidiControl(c) {
+			bidiTotal++
+			switch c {
+			case '\u202a', '\u202b', '\u202d', '\u202e', '\u2066', '\u2067', '\u2068':
+				depth++
+				if depth > maxDepth {
+					maxDepth = depth
+				}
+			case '\u202c', '\u2069':
+				if depth > 0 {
+					depth--
+				}
+			}
+		}
+	}
+
+	if cfg.MaxCombining > 0 && maxCombiningRun > cfg.MaxCombining {
+		points := 40
+		if maxCombiningRun > cfg.MaxCombining*4 {
+			points = 70
+		}
+		r.add(points, fmt.Sprintf("combining stack %d", maxCombiningRun))
+	}
+	if length >= 50 && combiningTotal*2 > length {
+		r.add(30, "combining ratio")
+	}
+	if cfg.MaxBidi > 0 && bidiTotal > cfg.MaxBidi {
+		points := 30
+		if bidiTotal > cfg.MaxBidi*4 {
+			points = 60
+		}
+		r.add(points, fmt.Sprintf("bidi controls %d", bidiTotal))
+	}
+	if cfg.MaxBidiDepth > 0 && maxDepth > cfg.MaxBidiDepth {
+		r.add(40, fmt.Sprintf("bidi depth %d", maxDepth))
+	}
+	if cfg.MaxInvisible > 0 && invisibleTotal > cfg.MaxInvisible {
+		points := 30
+		if invisibleTotal > cfg.MaxInvisible*4 {
+			points = 60
+		}
+		r.add(points, fmt.Sprintf("invisible chars %d", invisibleTotal))
+	}
+}
+
+// 🔍 Structure scoring (mentions, quoted nesting, malformed ContextInfo)
+func analyzeBugContext(ci *waProto.ContextInfo, cfg AntiBugConfig, depth int, r *BugReport) {
+	if ci == nil {
+		return
+	}
+
+	mentions := ci.GetMentionedJID()
+	if cfg.MaxMentions > 0 && len(mentions) > cfg.MaxMentions {
+		points := 40
+		if len(mentions) > cfg.MaxMentions*4 {
+			points = 70
+		}
+		r.add(points, fmt.Sprintf("mentions %d", len(mentions)))
+	}
+
+	malformed := 0
+	for _, m := range mentions {
+		jid, err := types.ParseJID(m)
+		if err != nil || jid.User == "" || len(jid.User) > 24 {
+			malformed++
+			continue
+		}
+		if jid.Server != types.DefaultUserServer && jid.Server != types.HiddenUserServer {
+			malformed++
+		}
+	}
+	if malformed > 0 {
+		points := 20
+		if malformed > 5 {
+			points = 60
+		}
+		r.add(points, fmt.Sprintf("malformed mentions %d", malformed))
+	}
+
+	if len(ci.GetStanzaID()) > 128 || len(ci.GetParticipant()) > 128 || len(ci.GetRemoteJID()) > 128 {
+		r.add(40, "oversized context ids")
+	}
+
+	if ci.QuotedMessage != nil {
+		if cfg.MaxQuoteDepth > 0 && depth+1 > cfg.MaxQuoteDepth {
+			r.add(60, fmt.Sprintf("quote depth %d", depth+1))
+			return
+		}
+		analyzeBugMessageInto(ci.QuotedMessage, cfg, depth+1, r)
+	}
+}
+
+// collectBugTexts ہر وہ ٹیکسٹ فیلڈ جس میں کریش پے لوڈ چھپ سکتا ہے
+func collectBugTexts(m *waProto.Message) []string {
+	texts := []string{m.GetConversation()}
+	if ext := m.GetExtendedTextMessage(); ext != nil {
+		texts = append(texts, ext.GetText(), ext.GetTitle(), ext.GetDescription(), ext.GetMatchedText())
+	}
+	texts = append(texts,
+		m.GetImageMessage().GetCaption(),
+		m.GetVideoMessage().GetCaption(),
+		m.GetDocumentMessage().GetCaption(),
+		m.GetDocumentMessage().GetFileName(),
+		m.GetDocumentMessage().GetTitle(),
+		m.GetContactMessage().GetDisplayName(),
+		m.GetContactMessage().GetVcard(),
+		m.GetLocationMessage().GetName(),
+		m.GetLocationMessage().GetAddress(),
+	)
+	if poll := m.GetPollCreationMessage(); poll != nil {
+		texts = append(texts, poll.GetName())
+		for _, opt := range poll.GetOptions() {
+			texts = append(texts, opt.GetOptionName())
+		}
+	}
+	return texts
+}
+
+func analyzeBugMessageInto(m *waProto.Message, cfg AntiBugConfig, depth int, r *BugReport) {
+	if m == nil {
+		return
+	}
+	analyzeBugText(strings.Join(collectBugTexts(m), "\n"), cfg, r)
+	analyzeBugContext(getContextInfo(m), cfg, depth, r)
+}
+
+// analyzeBugMessage پورے میسج کا اسکور نکالتا ہے
+func analyzeBugMessage(m *waProto.Message, cfg AntiBugConfig) BugReport {
+	var r BugReport
+	if m == nil {
+		return r
+	}
+	if cfg.MaxBytes > 0 {
+		if size := proto.Size(m); size > cfg.MaxBytes {
+			points := 30
+			if size > cfg.MaxBytes*4 {
+				points = 60
+			}
+			r.add(points, fmt.Sprintf("size %d bytes", size))
+		}
+	}
+	analyzeBugMessageInto(m, cfg, 0, &r)
+	return r
+}
+
+// ---------------------------------------------------------
+// 🚨 ENFORCEMENT (processMessage hook)
+// ---------------------------------------------------------
+// true کا مطلب ہے میسج روک دیا گیا، آگے پروسیس نہ کریں
+func handleIncomingBug(client *whatsmeow.Client, v *events.Message) bool {
+	if v.Info.IsFromMe {
+		return false
+	}
+
+	cfg := getAntiBugConfig()
+	report := analyzeBugMessage(v.Message, cfg)
+	if report.Score < cfg.Threshold {
+		return false
+	}
+
+	where := "DM"
+	if v.Info.IsGroup {
+		where = "GROUP " + v.Info.Chat.User
+	}
+	fmt.Printf("🛡️ MALICIOUS BUG DETECTED in %s! From: %s | Score: %d | %s\n",
+		where, v.Info.Sender.User, report.Score, strings.Join(report.Reasons, ", "))
+
+	if cfg.Action == "revoke" {
+		if v.Info.IsGroup {
+			client.SendMessage(context.Background(), v.Info.Chat, client.BuildRevoke(v.Info.Chat, v.Info.Sender, v.Info.ID))
+		} else {
+			client.RevokeMessage(context.Background(), v.Info.Chat, v.Info.ID)
+		}
+	}
+	return true
+}
+
+// ---------------------------------------------------------
+// ⚙️ COMMAND: .antibug [on|off|status|set <key> <n>|action <revoke|ignore>]
+// ---------------------------------------------------------
+func handleAntiBugConfig(client *whatsmeow.Client, v *events.Message, args []string) {
+	cfg := getAntiBugConfig()
+
+	switch strings.ToLower(args[0]) {
+	case "status":
+		status := "OFF ❌"
+		if AntiBugEnabled {
+			status = "ON ✅"
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 🛡️ ANTI-BUG
+╠════════════════╣
+║ Status: %s
+║ Action: %s
+║ Threshold: %d
+║ Length: %d
+║ Bytes: %d
+║ Combining: %d
+║ Bidi: %d (depth %d)
+║ Invisible: %d
+║ Mentions: %d
+║ Quote Depth: %d
+╚════════════════╝`, status, cfg.Action, cfg.Threshold, cfg.MaxLength, cfg.MaxBytes,
+			cfg.MaxCombining, cfg.MaxBidi, cfg.MaxBidiDepth, cfg.MaxInvisible, cfg.MaxMentions, cfg.MaxQuoteDepth)
+		replyMessage(client, v, msg)
+
+	case "action":
+		if !isOwner(client, v.Info.Sender) {
+			replyMessage(client, v, "❌ Owner Only")
+			return
+		}
+		if len(args) < 2 || (args[1] != "revoke" && args[1] != "ignore") {
+			replyMessage(client, v, "⚠️ Usage: .antibug action revoke | ignore")
+			return
+		}
+		cfg.Action = args[1]
+		saveAntiBugConfig(cfg)
+		replyMessage(client, v, "✅ Anti-Bug action: "+cfg.Action)
+
+	case "set":
+		if !isOwner(client, v.Info.Sender) {
+			replyMessage(client, v, "❌ Owner Only")
+			return
+		}
+		if len(args) < 3 {
+			replyMessage(client, v, "⚠️ Usage: .antibug set <threshold|length|bytes|combining|bidi|depth|invisible|mentions|quotes> <number>")
+			return
+		}
+		n, err := strconv.Atoi(args[2])
+		if err != nil || n < 0 {
+			replyMessage(client, v, "❌ Invalid number")
+			return
+		}
+		switch strings.ToLower(args[1]) {
+		case "threshold":
+			cfg.Threshold = n
+		case "length":
+			cfg.MaxLength = n
+		case "bytes":
+			cfg.MaxBytes = n
+		case "combining":
+			cfg.MaxCombining = n
+		case "bidi":
+			cfg.MaxBidi = n
+		case "depth":
+			cfg.MaxBidiDepth = n
+		case "invisible":
+			cfg.MaxInvisible = n
+		case "mentions":
+			cfg.MaxMentions = n
+		case "quotes":
+			cfg.MaxQuoteDepth = n
+		default:
+			replyMessage(client, v, "❌ Unknown key")
+			return
+		}
+		saveAntiBugConfig(cfg)
+		replyMessage(client, v, fmt.Sprintf("✅ Anti-Bug %s set to %d", strings.ToLower(args[1]), n))
+
+	case "reset":
+		if !isOwner(client, v.Info.Sender) {
+			replyMessage(client, v, "❌ Owner Only")
+			return
+		}
+		saveAntiBugConfig(defaultAntiBugConfig())
+		replyMessage(client, v, "✅ Anti-Bug thresholds reset to defaults")
+
+	default:
+		replyMessage(client, v, "⚠️ Usage: .antibug [on|off|status|action|set|reset]")
+	}
+}
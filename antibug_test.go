@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"google.golang.org/protobuf/proto"
+)
+
+// 🧪 Anti-bug corpus: clean messages must stay below the default threshold,
+// known crash payloads must reach it.
+
+type antiBugCase struct {
+	Name     string
+	Message  *waProto.Message
+	Expected bool
+}
+
+func bugTextMsg(text string) *waProto.Message {
+	return &waProto.Message{Conversation: proto.String(text)}
+}
+
+func bugMentionMsg(text string, mentions []string) *waProto.Message {
+	return &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:        proto.String(text),
+			ContextInfo: &waProto.ContextInfo{MentionedJID: mentions},
+		},
+	}
+}
+
+func bugNestedQuote(depth int) *waProto.Message {
+	msg := bugTextMsg("base")
+	for i := 0; i < depth; i++ {
+		msg = &waProto.Message{
+			ExtendedTextMessage: &waProto.ExtendedTextMessage{
+				Text: proto.String("reply"),
+				ContextInfo: &waProto.ContextInfo{
+					StanzaID:      proto.String("3EB0" + strconv.Itoa(i)),
+					QuotedMessage: msg,
+				},
+			},
+		}
+	}
+	return msg
+}
+
+func repeatedJIDs(n int, format string) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = fmt.Sprintf(format, 923000000000+i)
+	}
+	return out
+}
+
+func antiBugCorpus() []antiBugCase {
+	return []antiBugCase{
+		// ✅ Clean messages (must pass)
+		{"plain english", bugTextMsg("Assalam o Alaikum, meeting at 5pm today?"), false},
+		{"urdu text", bugTextMsg("السلام علیکم! آج شام پانچ بجے ملاقات ہے؟"), false},
+		{"emoji zwj family", bugTextMsg("👨\u200d👩\u200d👧\u200d👦 family photo 👩🏽\u200d💻"), false},
+		{"arabic with harakat", bugTextMsg("بِسْمِ ٱللَّٰهِ ٱلرَّحْمَٰنِ ٱلرَّحِيمِ"), false},
+		{"vietnamese accents", bugTextMsg("Tiếng Việt có dấu rất đẹp"), false},
+		{"rtl mark once", bugTextMsg("\u200fمرحبا 123"), false},
+		{"long normal text", bugTextMsg(strings.Repeat("Lorem ipsum dolor sit amet. ", 150)), false},
+		{"few mentions", bugMentionMsg("@a @b", repeatedJIDs(5, "%d@s.whatsapp.net")), false},
+		{"single reply", bugNestedQuote(1), false},
+
+		// 🚨 Crash payloads (must be caught)
+		{"zalgo flood", bugTextMsg("h" + strings.Repeat("\u0300\u0301\u0302\u0336", 60)), true},
+		{"combining only", bugTextMsg(strings.Repeat("a\u0336\u0337\u0338\u034f\u0489", 400)), true},
+		{"bidi override stack", bugTextMsg(strings.Repeat("\u202e\u202d\u202b\u202a", 50) + "text"), true},
+		{"isolate stack", bugTextMsg(strings.Repeat("\u2066\u2067\u2068", 40)), true},
+		{"zero width flood", bugTextMsg("hi" + strings.Repeat("\u200b", 600)), true},
+		{"mixed junk", bugTextMsg(strings.Repeat("\u2060\u200f\u200b", 200)), true},
+		{"huge message", bugTextMsg(strings.Repeat("A", 60000)), true},
+		{"mention flood", bugMentionMsg("@", repeatedJIDs(1500, "%d@s.whatsapp.net")), true},
+		{"malformed mentions", bugMentionMsg("@", repeatedJIDs(20, "%d@bogus.server")), true},
+		{"deep quotes", bugNestedQuote(8), true},
+		{"bug in caption", &waProto.Message{ImageMessage: &waProto.ImageMessage{
+			Caption: proto.String(strings.Repeat("\u202e\u0336\u200b", 300)),
+		}}, true},
+		{"bug in document name", &waProto.Message{DocumentMessage: &waProto.DocumentMessage{
+			FileName: proto.String(strings.Repeat("\u202e\u202d", 200) + ".pdf"),
+		}}, true},
+	}
+}
+
+func TestAnalyzeBugMessageCorpus(t *testing.T) {
+	cfg := defaultAntiBugConfig()
+	for _, c := range antiBugCorpus() {
+		t.Run(c.Name, func(t *testing.T) {
+			report := analyzeBugMessage(c.Message, cfg)
+			if got := report.Score >= cfg.Threshold; got != c.Expected {
+				t.Errorf("score %d (threshold %d), want bug=%v; reasons: %s",
+					report.Score, cfg.Threshold, c.Expected, strings.Join(report.Reasons, ", "))
+			}
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 💬 KEYWORD AUTO-RESPONDERS
+// .autoresponder add [exact|contains|regex] <trigger|"two words"> <reply>
+// Stored per group in the Redis hash autoresp:<bot>:<chat> (trigger -> JSON).
+// Checked in processMessage after the security filters, before commands.
+// Each trigger has its own per-group cooldown (groupCooldownLeft).
+// Every group message is checked, so the list is cached in RAM per group and
+// dropped whenever add / del / cooldown change it.
+
+const (
+	maxAutoResponders        = 50
+	defaultResponderCooldown = 30 // seconds
+)
+
+type AutoResponder struct {
+	Trigger  string       `json:"trigger"`
+	Match    string       `json:"match"` // exact | contains | regex
+	Text     string       `json:"text,omitempty"`
+	Media    *StoredMedia `json:"media,omitempty"`
+	Cooldown int          `json:"cooldown"` // seconds
+	By       string       `json:"by"`
+	Time     int64        `json:"time"`
+}
+
+var (
+	responderRegex    = make(map[string]*regexp.Regexp)
+	responderRegexMux sync.Mutex
+
+	responderCache    = make(map[string][]AutoResponder) // bot:chat -> list
+	responderCacheMux sync.RWMutex
+)
+
+func autoResponderKey(botID, chat string) string {
+	return "autoresp:" + botID + ":" + chat
+}
+
+// compileResponderRegex کمپائل شدہ پیٹرن کیش میں رکھتا ہے
+func compileResponderRegex(pattern string) (*regexp.Regexp, error) {
+	responderRegexMux.Lock()
+	defer responderRegexMux.Unlock()
+	if re, ok := responderRegex[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, err
+	}
+	responderRegex[pattern] = re
+	return re, nil
+}
+
+func (r *AutoResponder) matches(text string) bool {
+	lower := strings.ToLower(strings.TrimSpace(text))
+	switch r.Match {
+	case "exact":
+		return lower == r.Trigger
+	case "regex":
+		re, err := compileResponderRegex(r.Trigger)
+		return err == nil && re.MatchString(text)
+	default:
+		return strings.Contains(lower, r.Trigger)
+	}
+}
+
+// loadAutoResponders کیش پہلے، ورنہ Redis (خالی لسٹ بھی کیش ہوتی ہے)
+func loadAutoResponders(botID, chat string) []AutoResponder {
+	if rdb == nil {
+		return nil
+	}
+	cacheKey := botID + ":" + chat
+	responderCacheMux.RLock()
+	list, ok := responderCache[cacheKey]
+	responderCacheMux.RUnlock()
+	if ok {
+		return list
+	}
+
+	all, err := rdb.HGetAll(ctx, autoResponderKey(botID, chat)).Result()
+	if err != nil {
+		return nil
+	}
+	out := make([]AutoResponder, 0, len(all))
+	for _, raw := range all {
+		var r AutoResponder
+		if json.Unmarshal([]byte(raw), &r) == nil {
+			out = append(out, r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Trigger < out[j].Trigger })
+
+	responderCacheMux.Lock()
+	responderCache[cacheKey] = out
+	responderCacheMux.Unlock()
+	return out
+}
+
+func invalidateAutoResponders(botID, chat string) {
+	responderCacheMux.Lock()
+	delete(responderCache, botID+":"+chat)
+	responderCacheMux.Unlock()
+}
+
+// handleAutoResponder پہلا میچ ہونے والا جواب بھیجتا ہے؛ true = میسج سنبھال لیا
+func handleAutoResponder(client *whatsmeow.Client, v *events.Message, body string) bool {
+	if !v.Info.IsGroup || v.Info.IsFromMe || body == "" {
+		return false
+	}
+	botID := getCleanID(client.Store.ID.User)
+	chat := v.Info.Chat.String()
+
+	list := loadAutoResponders(botID, chat)
+	if len(list) == 0 {
+		return false
+	}
+	s := getGroupSettings(botID, chat)
+	if s.Mode == "private" || isCommandDisabled(s, "autoresponder") {
+		return false
+	}
+
+	for i := range list {
+		r := &list[i]
+		if !r.matches(body) {
+			continue
+		}
+		if groupCooldownLeft(v.Info.Chat, "ar:"+r.Trigger) > 0 {
+			return true // کول ڈاؤن میں، خاموش رہیں
+		}
+		if sendAutoResponse(client, v, r) == nil {
+			markGroupCooldown(v.Info.Chat, "ar:"+r.Trigger, time.Duration(r.Cooldown)*time.Second)
+		}
+		return true
+	}
+	return false
+}
+
+func sendAutoResponse(client *whatsmeow.Client, v *events.Message, r *AutoResponder) error {
+	ci := &waProto.ContextInfo{
+		StanzaID:      proto.String(v.Info.ID),
+		Participant:   proto.String(v.Info.Sender.String()),
+		QuotedMessage: v.Message,
+	}
+	if r.Media != nil {
+		_, err := client.SendMessage(context.Background(), v.Info.Chat, r.Media.toMessage(r.Text, ci))
+		if err == nil {
+			return nil
+		}
+		fmt.Printf("⚠️ [AUTORESPONDER] Media send failed: %v\n", err)
+		if r.Text == "" {
+			return err
+		}
+	}
+	_, err := client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:        proto.String(r.Text),
+			ContextInfo: ci,
+		},
+	})
+	return err
+}
+
+// parseResponderTrigger "quoted phrase" یا پہلا لفظ، باقی جواب
+func parseResponderTrigger(s string) (trigger, rest string) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, `"`) {
+		if end := strings.Index(s[1:], `"`); end >= 0 {
+			return s[1 : end+1], strings.TrimSpace(s[end+2:])
+		}
+	}
+	if idx := strings.IndexAny(s, " \n"); idx >= 0 {
+		return s[:idx], strings.TrimSpace(s[idx+1:])
+	}
+	return s, ""
+}
+
+// ---------------------------------------------------------
+// 💬 COMMAND: .autoresponder add|del|cooldown|list
+// ---------------------------------------------------------
+func handleAutoResponderCmd(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !hasGroupPermission(client, v, "autoresponder") {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Redis not connected.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	chat := v.Info.Chat.String()
+	key := autoResponderKey(botID, chat)
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+	// سب کمانڈ کے بعد والا خام ٹیکسٹ (quotes اور نئی لائنز سمیت)
+	rest := getCommandBody(v)
+	if idx := strings.IndexAny(rest, " \n"); idx >= 0 {
+		rest = strings.TrimSpace(rest[idx+1:])
+	} else {
+		rest = ""
+	}
+
+	switch sub {
+	case "add":
+		r := AutoResponder{
+			Match:    "contains",
+			Cooldown: defaultResponderCooldown,
+			By:       v.Info.Sender.ToNonAD().String(),
+			Time:     time.Now().Unix(),
+		}
+		if len(args) > 1 {
+			switch m := strings.ToLower(args[1]); m {
+			case "exact", "contains", "regex":
+				r.Match = m
+				rest = strings.TrimSpace(rest[len(m):])
+			}
+		}
+		r.Trigger, r.Text = parseResponderTrigger(rest)
+		if r.Match != "regex" {
+			r.Trigger = strings.ToLower(r.Trigger)
+		}
+		if r.Trigger == "" {
+			replyMessage(client, v, `⚠️ Usage: .autoresponder add [exact|contains|regex] <trigger> <reply>
+Use "quotes" for a trigger with spaces, or reply to a sticker/media.`)
+			return
+		}
+		if r.Match == "regex" {
+			if _, err := compileResponderRegex(r.Trigger); err != nil {
+				replyMessage(client, v, "❌ Invalid regex: "+err.Error())
+				return
+			}
+		}
+
+		if ci := getContextInfo(v.Message); ci != nil && ci.QuotedMessage != nil {
+			q := ci.QuotedMessage
+			if r.Text == "" {
+				r.Text = getText(q)
+			}
+			if q.ImageMessage != nil || q.VideoMessage != nil || q.StickerMessage != nil || q.AudioMessage != nil || q.DocumentMessage != nil {
+				media, err := captureMedia(client, q)
+				if err != nil {
+					replyMessage(client, v, "❌ Could not save the media: "+err.Error())
+					return
+				}
+				r.Media = media
+			}
+		}
+		if r.Text == "" && r.Media == nil {
+			replyMessage(client, v, "⚠️ The reply is empty. Add text after the trigger or reply to a message.")
+			return
+		}
+
+		if exists, _ := rdb.HExists(ctx, key, r.Trigger).Result(); !exists {
+			if n, _ := rdb.HLen(ctx, key).Result(); n >= maxAutoResponders {
+				replyMessage(client, v, fmt.Sprintf("❌ Limit reached (%d). Delete one first.", maxAutoResponders))
+				return
+			}
+		}
+		data, _ := json.Marshal(r)
+		rdb.HSet(ctx, key, r.Trigger, data)
+		invalidateAutoResponders(botID, chat)
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "autoresponder add", r.Match+": "+r.Trigger)
+
+		kind := "text"
+		if r.Media != nil {
+			kind = r.Media.Kind
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 💬 AUTO-REPLY ADDED
+╠════════════════╣
+║ 🔑 %s
+║ 🎯 %s
+║ 📦 %s
+║ ⏱️ %ds cooldown
+╚════════════════╝`, r.Trigger, r.Match, kind, r.Cooldown))
+		return
+
+	case "del", "remove":
+		trigger, _ := parseResponderTrigger(rest)
+		if trigger == "" {
+			replyMessage(client, v, "⚠️ Usage: .autoresponder del <trigger>")
+			return
+		}
+		n, _ := rdb.HDel(ctx, key, trigger).Result()
+		if n == 0 {
+			n, _ = rdb.HDel(ctx, key, strings.ToLower(trigger)).Result()
+		}
+		if n == 0 {
+			replyMessage(client, v, "❌ No auto-reply for *"+trigger+"*.")
+			return
+		}
+		invalidateAutoResponders(botID, chat)
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "autoresponder del", trigger)
+		replyMessage(client, v, "🗑️ Auto-reply *"+trigger+"* removed.")
+		return
+
+	case "cooldown", "cd":
+		trigger, secs := parseResponderTrigger(rest)
+		n, err := strconv.Atoi(strings.TrimSuffix(secs, "s"))
+		if trigger == "" || err != nil || n < 0 {
+			replyMessage(client, v, "⚠️ Usage: .autoresponder cooldown <trigger> <seconds>")
+			return
+		}
+		raw, err := rdb.HGet(ctx, key, trigger).Result()
+		if err != nil {
+			trigger = strings.ToLower(trigger)
+			raw, err = rdb.HGet(ctx, key, trigger).Result()
+		}
+		var r AutoResponder
+		if err != nil || json.Unmarshal([]byte(raw), &r) != nil {
+			replyMessage(client, v, "❌ No auto-reply for *"+trigger+"*.")
+			return
+		}
+		r.Cooldown = n
+		data, _ := json.Marshal(r)
+		rdb.HSet(ctx, key, trigger, data)
+		invalidateAutoResponders(botID, chat)
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "autoresponder cooldown", fmt.Sprintf("%s %ds", trigger, n))
+		replyMessage(client, v, fmt.Sprintf("⏱️ Cooldown for *%s* set to %ds.", trigger, n))
+		return
+	}
+
+	// list
+	var lines []string
+	for _, r := range loadAutoResponders(botID, chat) {
+		kind := "text"
+		if r.Media != nil {
+			kind = r.Media.Kind
+		}
+		lines = append(lines, fmt.Sprintf("🔑 %s (%s, %s, %ds)", r.Trigger, r.Match, kind, r.Cooldown))
+	}
+	if len(lines) == 0 {
+		lines = []string{"No auto-replies yet"}
+	}
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 💬 AUTO-REPLIES
+╠════════════════╣
+║ %s
+╠════════════════╣
+║ .ar add price Rs 500
+║ .ar add exact "link?" see rules
+║ .ar add regex ^hi+$ Hello!
+║ .ar cooldown price 60
+║ .ar del price
+╚════════════════╝`, strings.Join(lines, "\n║ ")))
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// 🚫 GROUP BAN LIST
+// Banned users are removed and kicked again if they rejoin. Join requests
+// from banned users are rejected by the join-request policy.
+
+// banNotInGroup بین لاگ کی وجہ جب کک کی ضرورت نہ تھی (.undo دوبارہ ایڈ نہیں کرتا)
+const banNotInGroup = "Not in the group"
+
+// isBanned کسی بھی ID (JID/LID/فون) سے چیک، نیٹ ورک بین سمیت
+func isBanned(s *GroupSettings, ids ...string) bool {
+	for _, b := range s.Banned {
+		for _, id := range ids {
+			if id != "" && b == getCleanID(id) {
+				return true
+			}
+		}
+	}
+	return networkBanned(s, ids...)
+}
+
+// altJID LID ↔ فون نمبر والی دوسری شکل (اسٹور میں نہ ہو تو خالی)
+func altJID(client *whatsmeow.Client, jid types.JID) types.JID {
+	var alt types.JID
+	switch jid.Server {
+	case types.HiddenUserServer:
+		alt, _ = client.Store.LIDs.GetPNForLID(context.Background(), jid.ToNonAD())
+	case types.DefaultUserServer:
+		alt, _ = client.Store.LIDs.GetLIDForPN(context.Background(), jid.ToNonAD())
+	}
+	return alt
+}
+
+// kickBannedJoiners نئے آنے والوں میں سے بین شدہ کو نکالتا ہے، باقی واپس کرتا ہے
+func kickBannedJoiners(client *whatsmeow.Client, chat types.JID, joined []types.JID, s *GroupSettings) []types.JID {
+	if len(s.Banned) == 0 && s.Network == "" {
+		return joined
+	}
+
+	var kept, banned []types.JID
+	for _, j := range joined {
+		if isBanned(s, j.User, altJID(client, j).User) {
+			banned = append(banned, j)
+		} else {
+			kept = append(kept, j)
+		}
+	}
+	if len(banned) == 0 {
+		return joined
+	}
+
+	_, err := client.UpdateGroupParticipants(context.Background(), chat, banned, whatsmeow.ParticipantChangeRemove)
+	if err == nil {
+		for _, b := range banned {
+			logModAction(client, chat, types.EmptyJID, b, "kick", "banned user rejoined")
+			sendGroupNotice(client, chat, b, fmt.Sprintf(`╔════════════════╗
+║ 🚫 BANNED USER
+╠════════════════╣
+║ 👤 @%s
+║ Removed on join
+╚════════════════╝`, b.User))
+		}
+	}
+	return kept
+}
+
+// ---------------------------------------------------------
+// 🚫 COMMANDS: .ban @user | .unban @user | .ban (list)
+// ---------------------------------------------------------
+func handleBan(client *whatsmeow.Client, v *events.Message, args []string, ban bool) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	target, err := getTargetJID(v, args)
+	if err != nil || target.User == "" {
+		if !ban {
+			replyMessage(client, v, "⚠️ Usage: .unban @user (or number)")
+			return
+		}
+		list := "Empty"
+		if len(s.Banned) > 0 {
+			list = strings.Join(s.Banned, "\n║ ")
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🚫 BAN LIST (%d)
+╠════════════════╣
+║ %s
+╠════════════════╣
+║ .ban @user
+║ .unban @user
+╚════════════════╝`, len(s.Banned), list))
+		return
+	}
+
+	id := getCleanID(target.User)
+	if !ban {
+		// نئی سلائس: isBanned دوسرے گوروٹینز میں پرانی لسٹ پڑھ رہا ہو سکتا ہے
+		kept := make([]string, 0, len(s.Banned))
+		for _, b := range s.Banned {
+			if b != id {
+				kept = append(kept, b)
+			}
+		}
+		s.Banned = kept
+		saveGroupSettings(botID, s)
+		setNetworkBan(client, s, target, false)
+		logModAction(client, v.Info.Chat, v.Info.Sender, target, "unban", "")
+		replyMessage(client, v, "✅ @"+target.User+" unbanned")
+		return
+	}
+
+	if isAdmin(client, v.Info.Chat, target) {
+		replyMessage(client, v, "❌ Cannot ban an admin.")
+		return
+	}
+	// پہلے کک؛ ممبر نہ ہو (404) تو بھی بین لسٹ میں، باقی ناکامی پر کچھ نہیں بدلتا
+	status := "Removed"
+	res, err := client.UpdateGroupParticipants(context.Background(), v.Info.Chat, []types.JID{target}, whatsmeow.ParticipantChangeRemove)
+	if err == nil && len(res) > 0 && res[0].Error == 404 {
+		status = banNotInGroup
+	} else if err == nil && len(res) > 0 && res[0].Error != 0 {
+		err = fmt.Errorf("WhatsApp refused (code %d)", res[0].Error)
+	}
+	if err != nil {
+		groupResult(client, v, "BAN", "", err)
+		return
+	}
+
+	var added bool
+	if s.Banned, added = toggleList(s.Banned, id, true); added {
+		saveGroupSettings(botID, s)
+	}
+	logModAction(client, v.Info.Chat, v.Info.Sender, target, "ban", status)
+
+	scope := ""
+	if n := groupNetwork(s); n != nil && n.ShareBans {
+		removed := setNetworkBan(client, s, target, true)
+		scope = fmt.Sprintf("\n║ 🕸️ Network: %s\n║ Removed from %d more", n.Name, removed)
+	}
+	sendGroupNotice(client, v.Info.Chat, target, fmt.Sprintf(`╔════════════════╗
+║ 🚫 BANNED
+╠════════════════╣
+║ 👤 @%s
+║ %s, will be
+║ kicked on rejoin%s
+╚════════════════╝`, target.User, status, scope))
+}
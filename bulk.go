@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// 🧹 BULK MEMBER MANAGEMENT
+// .kick inactive 30d | .kick prefix +91 | .kick list <numbers...>
+// Always shows a preview first; the removal itself goes through confirmAction.
+// Removals go out in small batches so WhatsApp does not rate-limit the bot.
+
+const (
+	bulkBatchSize  = 5
+	bulkBatchDelay = 3 * time.Second
+)
+
+// participantIDs ممبر کی تمام ممکنہ IDs (JID / LID / فون نمبر)
+func participantIDs(p types.GroupParticipant) []string {
+	ids := []string{getCleanID(p.JID.User)}
+	if !p.LID.IsEmpty() {
+		ids = append(ids, getCleanID(p.LID.User))
+	}
+	if !p.PhoneNumber.IsEmpty() {
+		ids = append(ids, getCleanID(p.PhoneNumber.User))
+	}
+	return ids
+}
+
+// participantPhone فون نمبر (اگر معلوم ہو)
+func participantPhone(p types.GroupParticipant) string {
+	if !p.PhoneNumber.IsEmpty() {
+		return p.PhoneNumber.User
+	}
+	if p.JID.Server == types.DefaultUserServer {
+		return p.JID.User
+	}
+	return ""
+}
+
+func isBotParticipant(client *whatsmeow.Client, p types.GroupParticipant) bool {
+	for _, id := range participantIDs(p) {
+		if client.Store.ID != nil && id == getCleanID(client.Store.ID.User) {
+			return true
+		}
+		if !client.Store.LID.IsEmpty() && id == getCleanID(client.Store.LID.User) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDays "30d" یا "30" کو دنوں میں
+func parseDays(arg string) (int, bool) {
+	n, err := strconv.Atoi(strings.TrimSuffix(strings.ToLower(arg), "d"))
+	if err != nil || n < 1 || n > 365 {
+		return 0, false
+	}
+	return n, true
+}
+
+// ---------------------------------------------------------
+// 🧹 .kick inactive|prefix|list
+// ---------------------------------------------------------
+func handleBulkKick(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+
+	usage := `╔════════════════╗
+║ 🧹 BULK KICK
+╠════════════════╣
+║ .kick inactive 30d
+║ .kick prefix +91
+║ .kick list 92300.. 92301..
+╚════════════════╝`
+
+	mode := strings.ToLower(args[0])
+	if len(args) < 2 {
+		replyMessage(client, v, usage)
+		return
+	}
+
+	info, err := client.GetGroupInfo(context.Background(), v.Info.Chat)
+	if err != nil {
+		replyMessage(client, v, "❌ Failed to fetch group info.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	var (
+		targets []types.JID
+		labels  []string
+		title   string
+		note    string
+	)
+
+	add := func(p types.GroupParticipant) {
+		targets = append(targets, p.JID)
+		label := participantPhone(p)
+		if label == "" {
+			label = p.JID.User
+		}
+		labels = append(labels, label)
+	}
+
+	switch mode {
+	case "inactive":
+		days, ok := parseDays(args[1])
+		if !ok {
+			replyMessage(client, v, "⚠️ Usage: .kick inactive 30d")
+			return
+		}
+		seen, since := loadLastSeen(botID, v.Info.Chat.String())
+		cutoff := time.Now().AddDate(0, 0, -days)
+		if since.IsZero() || since.After(cutoff) {
+			// اتنے دن کا ڈیٹا ابھی موجود نہیں، بغیر ریکارڈ والوں کو نہیں نکالیں گے
+			note = "\n║ ⚠️ Tracking is newer\n║ than " + strconv.Itoa(days) + "d, members with\n║ no record are skipped."
+		}
+
+		for _, p := range info.Participants {
+			if p.IsAdmin || p.IsSuperAdmin || isBotParticipant(client, p) {
+				continue
+			}
+			var last int64
+			for _, id := range participantIDs(p) {
+				if seen[id] > last {
+					last = seen[id]
+				}
+			}
+			if last == 0 {
+				if since.IsZero() || since.After(cutoff) {
+					continue
+				}
+			} else if time.Unix(last, 0).After(cutoff) {
+				continue
+			}
+			add(p)
+		}
+		title = fmt.Sprintf("Inactive %dd", days)
+
+	case "prefix":
+		prefix := strings.TrimPrefix(strings.TrimSpace(args[1]), "+")
+		if _, err := strconv.Atoi(prefix); err != nil {
+			replyMessage(client, v, "⚠️ Usage: .kick prefix +91")
+			return
+		}
+		for _, p := range info.Participants {
+			if p.IsAdmin || p.IsSuperAdmin || isBotParticipant(client, p) {
+				continue
+			}
+			if phone := participantPhone(p); phone != "" && strings.HasPrefix(phone, prefix) {
+				add(p)
+			}
+		}
+		title = "Prefix +" + prefix
+
+	case "list":
+		wanted := make(map[string]bool)
+		for _, a := range args[1:] {
+			for _, n := range strings.Split(a, ",") {
+				n = strings.NewReplacer("+", "", "@", "", "-", "").Replace(strings.TrimSpace(n))
+				if n != "" {
+					wanted[n] = true
+				}
+			}
+		}
+		for _, p := range info.Participants {
+			if p.IsAdmin || p.IsSuperAdmin || isBotParticipant(client, p) {
+				continue
+			}
+			for _, id := range participantIDs(p) {
+				if wanted[id] {
+					add(p)
+					break
+				}
+			}
+		}
+		title = "List"
+
+	default:
+		replyMessage(client, v, usage)
+		return
+	}
+
+	if len(targets) == 0 {
+		replyMessage(client, v, "✅ No matching members found (admins are never included).")
+		return
+	}
+
+	preview := labels
+	more := ""
+	if len(preview) > 20 {
+		more = fmt.Sprintf("\n║ ...and %d more", len(preview)-20)
+		preview = preview[:20]
+	}
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🧹 DRY RUN
+╠════════════════╣
+║ 🎯 %s
+║ 👥 %d of %d members%s
+╠════════════════╣
+║ %s%s
+╚════════════════╝`, title, len(targets), len(info.Participants), note, strings.Join(preview, "\n║ "), more)
+	replyMessage(client, v, msg)
+
+	if !confirmAction(client, v, "kick bulk", fmt.Sprintf("Remove %d members?", len(targets))) {
+		return
+	}
+
+	removed, failed := bulkRemove(client, v.Info.Chat, targets)
+	logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "bulk kick", fmt.Sprintf("%s: %d removed, %d failed", title, removed, failed))
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🧹 BULK KICK DONE
+╠════════════════╣
+║ ✅ Removed: %d
+║ ❌ Failed: %d
+╚════════════════╝`, removed, failed))
+}
+
+// bulkRemove بیچز میں ممبرز نکالتا ہے
+func bulkRemove(client *whatsmeow.Client, chat types.JID, targets []types.JID) (int, int) {
+	removed, failed := 0, 0
+	for i := 0; i < len(targets); i += bulkBatchSize {
+		end := i + bulkBatchSize
+		if end > len(targets) {
+			end = len(targets)
+		}
+
+		res, err := client.UpdateGroupParticipants(context.Background(), chat, targets[i:end], whatsmeow.ParticipantChangeRemove)
+		if err != nil {
+			fmt.Printf("⚠️ [BULK] Batch failed: %v\n", err)
+			failed += end - i
+		} else if len(res) == 0 {
+			removed += end - i
+		} else {
+			for _, p := range res {
+				if p.Error != 0 {
+					failed++
+				} else {
+					removed++
+				}
+			}
+		}
+
+		if end < len(targets) {
+			time.Sleep(bulkBatchDelay)
+		}
+	}
+	return removed, failed
+}
@@ -143,6 +143,12 @@ func handler(botClient *whatsmeow.Client, evt interface{}) {
 			}
 		}()
 
+	case *events.GroupInfo:
+		handleGroupEvents(botClient, v)
+
+	case *events.Picture:
+		go handleLockPicture(botClient, v)
+
 	case *events.Connected:
 		if botClient.Store != nil && botClient.Store.ID != nil {
 			fmt.Printf("🟢 [ONLINE] Bot %s connected!\n", botClient.Store.ID.User)
@@ -187,7 +193,7 @@ func canExecute(client *whatsmeow.Client, v *events.Message, cmd string) bool {
 	s := getGroupSettings(botID, v.Info.Chat.String())
 	
 	if s.Mode == "private" { return false }
-	if s.Mode == "admin" { return isAdmin(client, v.Info.Chat, v.Info.Sender) }
+	if s.Mode == "admin" { return isAdmin(client, v.Info.Chat, v.Info.Sender) || roleAllows(s, v, cmd) }
 	
 	return true
 }
@@ -206,7 +212,50 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		return
 	}
 
-	// ⚡ 3. Text & Type Extraction
+	// =========================================================
+	// 🛡️ 3. IMMEDIATE ANTI-BUG PROTECTION (DMs + Groups)
+	// =========================================================
+	// ٹیکسٹ چیک سے پہلے تاکہ خالی ٹیکسٹ والے (ڈاکیومنٹ، mentions) بگ بھی پکڑے جائیں
+	if AntiBugEnabled && handleIncomingBug(client, v) {
+		return
+	}
+
+	// 📊 Poll votes (ان میں ٹیکسٹ نہیں ہوتا)
+	if v.Message.GetPollUpdateMessage() != nil {
+		go handlePollVote(client, v)
+		return
+	}
+
+	// 👆 Admin reactions (ان میں بھی ٹیکسٹ نہیں ہوتا)
+	if v.Message.GetReactionMessage() != nil {
+		go handleReactionModeration(client, v)
+		return
+	}
+
+	// 🔇 Muted members
+	if handleMutedMember(client, v) {
+		return
+	}
+
+	// 🕸️ Network word filter
+	if handleNetworkFilter(client, v) {
+		return
+	}
+
+	// 🐢 Slow Mode (میڈیا/سٹیکر پر بھی لاگو، اس لیے ٹیکسٹ چیک سے پہلے)
+	if handleSlowMode(client, v) {
+		return
+	}
+
+	// 📊 Activity counters + last seen (صرف گنتی، میسج نہیں)
+	if v.Info.IsGroup {
+		go recordGroupActivity(getCleanID(client.Store.ID.User), v)
+	}
+
+	// 💤 AFK: واپسی اور مینشنز (میڈیا/سٹیکر پر بھی)
+	go handleAFK(client, v)
+
+	// ⚡ 4. Text & Type Extraction
 	bodyRaw := getText(v.Message)
 	isAudio := v.Message.GetAudioMessage() != nil // 🔥 Check if it's Audio
 
@@ -218,6 +267,11 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 	}
 	bodyClean := strings.TrimSpace(bodyRaw)
 
+	// 🧩 Join captcha جواب (DM)
+	if !v.Info.IsGroup && handleJoinCaptchaReply(client, v, bodyClean) {
+		return
+	}
+
 	// =========================================================
 	// 🔥 AI & HISTORY LOGIC
 	// =========================================================
@@ -235,22 +289,6 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 
     // ... باقی کوڈ ویسا ہی رہنے دیں ...
 
-	// =========================================================
-	// 🛡️ 4. IMMEDIATE ANTI-BUG PROTECTION (Private Chats Only)
-	// =========================================================
-	if AntiBugEnabled && !v.Info.IsGroup {
-		badChars := []string{"\u200b", "\u202e", "\u202d", "\u2060", "\u200f"}
-		totalJunk := 0
-		for _, char := range badChars {
-			totalJunk += strings.Count(bodyClean, char)
-		}
-		if totalJunk > 50 {
-			fmt.Printf("🛡️ MALICIOUS BUG DETECTED in DM! From: %s | Cleaning...\n", v.Info.Sender.User)
-			client.RevokeMessage(context.Background(), v.Info.Chat, v.Info.ID)
-			return
-		}
-	}
-
 	// 🟢 Variables Extraction
 	chatID := v.Info.Chat.String()
 	senderID := v.Info.Sender.ToNonAD().String()
@@ -278,8 +316,14 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		}()
 
 		// 🛑 REPLY INTERCEPTOR (یہ نیا کوڈ ہے جو آپ کے ڈاؤنلوڈر کو جواب پہنچائے گا)
+		// پہلے اسی چیٹ تک محدود انتظار (WaitForChatReply)، پھر عام
+		replyKey := chatReplyKey(v.Info.Chat, senderID)
 		replyMutex.RLock()
-		ch, waiting := replyChannels[senderID]
+		ch, waiting := replyChannels[replyKey]
+		if !waiting {
+			replyKey = senderID
+			ch, waiting = replyChannels[replyKey]
+		}
 		replyMutex.RUnlock()
 
 		if waiting {
@@ -288,7 +332,7 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 				ch <- bodyClean
 				
 				replyMutex.Lock()
-				delete(replyChannels, senderID) // چینل صاف کریں
+				delete(replyChannels, replyKey) // چینل صاف کریں
 				replyMutex.Unlock()
 				return
 			}
@@ -410,6 +454,11 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 			}
 		}
 
+		// 📝 #name نوٹ شارٹ ہینڈ
+		if !isCommand && handleNoteShorthand(client, v, bodyClean) {
+			return
+		}
+
 		// ⚡ D. SECURITY CHECKS (OPTIMIZED)
 		if !isCommand && v.Info.IsGroup {
 			hasLink := false
@@ -450,22 +499,21 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 			isVideo := v.Message.VideoMessage != nil
 			isSticker := v.Message.StickerMessage != nil
 
-			if !hasLink && !isImage && !isVideo && !isSticker {
-				return
-			}
+			// صاف ٹیکسٹ آگے جائے گا (auto-responders)
+			if hasLink || isImage || isVideo || isSticker {
+				s := getGroupSettings(botID, chatID)
+				if s.Mode == "private" { return }
 
-			s := getGroupSettings(botID, chatID)
-			if s.Mode == "private" { return }
+				shouldCheck := false
+				if hasLink && s.Antilink { shouldCheck = true }
+				if isImage && s.AntiPic { shouldCheck = true }
+				if isVideo && s.AntiVideo { shouldCheck = true }
+				if isSticker && s.AntiSticker { shouldCheck = true }
 
-			shouldCheck := false
-			if hasLink && s.Antilink { shouldCheck = true }
-			if isImage && s.AntiPic { shouldCheck = true }
-			if isVideo && s.AntiVideo { shouldCheck = true }
-			if isSticker && s.AntiSticker { shouldCheck = true }
-
-			if shouldCheck {
-				checkSecurity(client, v)
-				return 
+				if shouldCheck {
+					checkSecurity(client, v)
+					return 
+				}
 			}
 		}
 
@@ -476,6 +524,11 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 			}
 		}
 
+		// 💬 Keyword auto-responders
+		if !isCommand && handleAutoResponder(client, v, bodyClean) {
+			return
+		}
+
 		// =========================================================
 		// 🚀 E. COMMAND HANDLING (Final Step)
 		// =========================================================
@@ -503,6 +556,12 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 			return
 		}
 
+		// 🚫 اس گروپ میں بند کمانڈ (اونر پر لاگو نہیں)
+		if v.Info.IsGroup && !isOwner(client, v.Info.Sender) && isCommandDisabled(getGroupSettings(botID, chatID), cmd) {
+			replyMessage(client, v, "🚫 *"+prefix+cmd+"* is disabled in this group.")
+			return
+		}
+
 		// Log Command
 		fmt.Printf("🚀 [EXEC] Bot:%s | CMD:%s\n", botID, cmd)
 
@@ -527,16 +586,164 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 				return
 			}
 			s := getGroupSettings(botID, chatID)
+			var on bool
 			if fullArgs == "on" || fullArgs == "enable" {
-				s.Welcome = true
+				on = true
 				replyMessage(client, v, "✅ *Welcome Messages:* ON")
 			} else if fullArgs == "off" || fullArgs == "disable" {
-				s.Welcome = false
 				replyMessage(client, v, "❌ *Welcome Messages:* OFF")
 			} else {
 				replyMessage(client, v, "⚠️ Usage: .welcome on | off")
+				return
+			}
+			// لاگ صرف تب جب سیٹنگ واقعی بدلی
+			if s.Welcome != on {
+				s.Welcome = on
+				saveGroupSettings(botID, s)
+				logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "welcome", onOff(on))
 			}
-			saveGroupSettings(botID, s)
+
+		case "setwelcome":
+			react(client, v.Info.Chat, v.Info.ID, "📝")
+			handleSetGreeting(client, v, args, false)
+
+		case "setgoodbye":
+			react(client, v.Info.Chat, v.Info.ID, "📝")
+			handleSetGreeting(client, v, args, true)
+
+		case "testwelcome":
+			react(client, v.Info.Chat, v.Info.ID, "👀")
+			handleTestWelcome(client, v, false)
+
+		case "testgoodbye":
+			react(client, v.Info.Chat, v.Info.ID, "👀")
+			handleTestWelcome(client, v, true)
+
+		case "rules":
+			react(client, v.Info.Chat, v.Info.ID, "📜")
+			handleRules(client, v, args)
+
+		case "setrules":
+			react(client, v.Info.Chat, v.Info.ID, "📝")
+			handleSetRules(client, v, args)
+
+		case "lockinfo":
+			react(client, v.Info.Chat, v.Info.ID, "🔐")
+			handleLockInfo(client, v, args)
+
+		case "slowmode":
+			react(client, v.Info.Chat, v.Info.ID, "🐢")
+			handleSlowModeCmd(client, v, args)
+
+		case "topactive":
+			react(client, v.Info.Chat, v.Info.ID, "🏆")
+			handleTopActive(client, v, args)
+
+		case "mystats":
+			react(client, v.Info.Chat, v.Info.ID, "📈")
+			handleMyStats(client, v)
+
+		case "inactive":
+			react(client, v.Info.Chat, v.Info.ID, "💤")
+			handleInactive(client, v, args)
+
+		case "poll":
+			handlePoll(client, v)
+
+		case "pollresult":
+			react(client, v.Info.Chat, v.Info.ID, "📊")
+			handlePollResult(client, v)
+
+		case "pollclose":
+			react(client, v.Info.Chat, v.Info.ID, "🏁")
+			handlePollClose(client, v)
+
+		case "requests":
+			react(client, v.Info.Chat, v.Info.ID, "🛂")
+			handleRequests(client, v, args)
+
+		case "ban":
+			react(client, v.Info.Chat, v.Info.ID, "🚫")
+			handleBan(client, v, args, true)
+
+		case "unban":
+			react(client, v.Info.Chat, v.Info.ID, "✅")
+			handleBan(client, v, args, false)
+
+		case "role", "roles":
+			react(client, v.Info.Chat, v.Info.ID, "🎖️")
+			handleRole(client, v, args)
+
+		case "mute":
+			react(client, v.Info.Chat, v.Info.ID, "🔇")
+			handleMute(client, v, args, true)
+
+		case "unmute":
+			react(client, v.Info.Chat, v.Info.ID, "🔊")
+			handleMute(client, v, args, false)
+
+		case "disable":
+			react(client, v.Info.Chat, v.Info.ID, "🚫")
+			handleToggleCommand(client, v, args, true)
+
+		case "enable":
+			react(client, v.Info.Chat, v.Info.ID, "✅")
+			handleToggleCommand(client, v, args, false)
+
+		case "disabled":
+			react(client, v.Info.Chat, v.Info.ID, "📋")
+			handleDisabledList(client, v)
+
+		case "modlog":
+			react(client, v.Info.Chat, v.Info.ID, "📒")
+			handleModLog(client, v, args)
+
+		case "undo":
+			react(client, v.Info.Chat, v.Info.ID, "↩️")
+			handleUndo(client, v)
+
+		case "network", "net":
+			react(client, v.Info.Chat, v.Info.ID, "🕸️")
+			handleNetwork(client, v, args)
+
+		case "reactmod":
+			react(client, v.Info.Chat, v.Info.ID, "👆")
+			handleReactMod(client, v, args)
+
+		case "save":
+			react(client, v.Info.Chat, v.Info.ID, "📝")
+			handleSaveNote(client, v, args)
+
+		case "get":
+			handleGetNote(client, v, args)
+
+		case "notes":
+			react(client, v.Info.Chat, v.Info.ID, "📝")
+			handleListNotes(client, v)
+
+		case "clear":
+			react(client, v.Info.Chat, v.Info.ID, "🗑️")
+			handleClearNote(client, v, args)
+
+		case "autoresponder", "ar":
+			react(client, v.Info.Chat, v.Info.ID, "💬")
+			handleAutoResponderCmd(client, v, args)
+
+		case "addcmd":
+			react(client, v.Info.Chat, v.Info.ID, "🧩")
+			handleAddCustomCmd(client, v, args)
+
+		case "delcmd":
+			react(client, v.Info.Chat, v.Info.ID, "🗑️")
+			handleDelCustomCmd(client, v, args)
+
+		case "afk":
+			react(client, v.Info.Chat, v.Info.ID, "💤")
+			handleAFKCmd(client, v)
+
+		case "confirm":
+			react(client, v.Info.Chat, v.Info.ID, "✅")
+			handleConfirmCmd(client, v, args)
 
 		case "setprefix":
 			react(client, v.Info.Chat, v.Info.ID, "🔧")
@@ -613,7 +820,7 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		
 		case "antibug":
 			react(client, v.Info.Chat, v.Info.ID, "🛡️")
-			handleAntiBug(client, v)
+			handleAntiBug(client, v, args)
 		
 		case "send":
 			react(client, v.Info.Chat, v.Info.ID, "📤")
@@ -654,6 +861,17 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		case "kick":
 			react(client, v.Info.Chat, v.Info.ID, "👢")
 			handleKick(client, v, words[1:])
+
+		case "warn":
+			react(client, v.Info.Chat, v.Info.ID, "⚠️")
+			handleWarn(client, v, words[1:])
+
+		case "report":
+			handleReport(client, v, words[1:])
+
+		case "reportto":
+			react(client, v.Info.Chat, v.Info.ID, "📬")
+			handleReportTo(client, v, words[1:])
 		
 		case "add":
 			react(client, v.Info.Chat, v.Info.ID, "➕")
@@ -944,6 +1162,10 @@ func processMessage(client *whatsmeow.Client, v *events.Message) {
 		case "dl", "download", "mega":
 			react(client, v.Info.Chat, v.Info.ID, "📥")
 			handleMega(client, v, fullArgs)
+
+		default:
+			// 🧩 صارف کی بنائی ہوئی کمانڈز
+			handleCustomCommand(client, v, cmd, args)
 		}
 	}()
 }
@@ -1147,6 +1369,10 @@ func sendMenu(client *whatsmeow.Client, v *events.Message) {
 	currentMode := strings.ToUpper(s.Mode)
 	if !v.Info.IsGroup { currentMode = "PRIVATE" }
 
+	// گروپ میں بند کمانڈز مینو میں نہیں دکھیں گی
+	var groupSettings *GroupSettings
+	if v.Info.IsGroup { groupSettings = s }
+
 	menu := fmt.Sprintf(`╔══════════════════════╗
 ║    ✨ %s ✨      
 ╠══════════════════════╣
@@ -1154,130 +1380,8 @@ func sendMenu(client *whatsmeow.Client, v *events.Message) {
 ║ 🛡️ *Mode:* %s
 ║ ⏳ *Uptime:* %s
 ╠══════════════════════╣
-║
-║ ╭── 🎬 MOVIE & STREAMS ──╮
-║ │ 🔸 *%smovie* - Movie Download
-║ │ 🔸 *%syt* - YouTube Video
-║ │ 🔸 *%syts* - YT Search
-║ │ 🔸 *%sdm* - DailyMotion
-║ │ 🔸 *%svimeo* - Vimeo Pro
-║ │ 🔸 *%srumble* - Rumble
-║ │ 🔸 *%sbilibili* - Anime
-║ │ 🔸 *%sdouyin* - Chinese TT
-║ │ 🔸 *%skwai* - Kwai Video
-║ │ 🔸 *%sbitchute* - BitChute
-║ │ 🔸 *%sted* - TED Talks
-║ │ 🔸 *%stwitch* - Twitch Clips
-║ ╰───────────────────────╯
-║
-║ ╭─── 🎵 MUSIC STUDIO ────╮
-║ │ 🔸 *%sspotify* - Spotify
-║ │ 🔸 *%ssc* - SoundCloud
-║ │ 🔸 *%sapple* - Apple Music
-║ │ 🔸 *%sdeezer* - Deezer
-║ │ 🔸 *%stidal* - Tidal HQ
-║ │ 🔸 *%smixcloud* - DJ Sets
-║ │ 🔸 *%snapster* - Napster
-║ │ 🔸 *%sbandcamp* - Indie
-║ ╰───────────────────────╯
-║
-║ ╭── 📱 SOCIAL MEDIA ─────╮
-║ │ 🔸 *%sfb* - Facebook
-║ │ 🔸 *%sig* - Instagram
-║ │ 🔸 *%stt* - TikTok (No-WM)
-║ │ 🔸 *%stw* - Twitter/X
-║ │ 🔸 *%spin* - Pinterest
-║ │ 🔸 *%ssnap* - Snapchat
-║ │ 🔸 *%sthreads* - Threads
-║ │ 🔸 *%sreddit* - Reddit
-║ │ 🔸 *%s9gag* - 9GAG Fun
-║ │ 🔸 *%sifunny* - iFunny Memes
-║ ╰───────────────────────╯
-║
-║ ╭── 🌐 WEB & SEARCH ────╮
-║ │ 🔸 *%smega* - Mega/File DL
-║ │ 🔸 *%sgit* - GitHub Repo
-║ │ 🔸 *%simgur* - Imgur Media
-║ │ 🔸 *%sarchive* - Web Archive
-║ │ 🔸 *%ssteam* - Steam Games
-║ │ 🔸 *%sgiphy* - GIF Search
-║ │ 🔸 *%sflickr* - Flickr Image
-║ │ 🔸 *%sgoogle* - Google Search
-║ │ 🔸 *%sweather* - Weather Info
-║ ╰───────────────────────╯
-║
-║ ╭─── 🧠 AI & UTILS ─────╮
-║ │ 🔸 *%sai* - Gemini AI
-║ │ 🔸 *%sgpt* - Chat GPT-4o
-║ │ 🔸 *%simg* - Image Gen
-║ │ 🔸 *%sremini* - HD Upscale
-║ │ 🔸 *%sremovebg* - BG Remove
-║ │ 🔸 *%str* - Translate
-║ │ 🔸 *%sfancy* - Fancy Text
-║ │ 🔸 *%sss* - Screenshot
-║ │ 🔸 *%sstats* - System Stats
-║ │ 🔸 *%sspeed* - Internet Speed
-║ │ 🔸 *%sping* - Bot Response
-║ │ 🔸 *%sid* - Chat/User ID
-║ │ 🔸 *%sdata* - Data Status
-║ │ 🔸 *%sowner* - Owner Card
-║ ╰───────────────────────╯
-║
-║ ╭─── 🎨 MEDIA TOOLS ────╮
-║ │ 🔸 *%ssticker* - To Sticker
-║ │ 🔸 *%stoimg* - Sticker2Img
-║ │ 🔸 *%stogif* - Sticker2Gif
-║ │ 🔸 *%stovideo* - Sticker2Vid
-║ │ 🔸 *%stourl* - Media URL
-║ │ 🔸 *%stoptt* - Text to Audio
-║ │ 🔸 *%svv* - Anti-ViewOnce
-║ ╰───────────────────────╯
-║
-║ ╭── 👥 GROUP ADMIN ─────╮
-║ │ 🔸 *%sadd* - Add User
-║ │ 🔸 *%skick* - Kick User
-║ │ 🔸 *%spromote* - Make Admin
-║ │ 🔸 *%sdemote* - Demote
-║ │ 🔸 *%sgroup* - Settings
-║ │ 🔸 *%stagall* - Tag All
-║ │ 🔸 *%shidetag* - Hidden Tag
-║ │ 🔸 *%swelcome* - Welcome
-║ │ 🔸 *%sdel* - Delete Msg
-║ ╰───────────────────────╯
-║
-║ ╭── 🛡️ GROUP SECURITY ──╮
-║ │ 🔸 *%smode* - Public/Admin
-║ │ 🔸 *%santilink* - Block Links
-║ │ 🔸 *%santipic* - Block Pics
-║ │ 🔸 *%santivideo* - Block Vids
-║ │ 🔸 *%santisticker* - Block Sticker
-║ ╰───────────────────────╯
-║
-║ ╭── ⚙️ OWNER CONTROL ───╮
-║ │ 🔸 *%ssetprefix* - Set Prefix
-║ │ 🔸 *%salwaysonline* - 24/7 On
-║ │ 🔸 *%sautoread* - Auto Seen
-║ │ 🔸 *%sautoreact* - Auto Like
-║ │ 🔸 *%sautostatus* - View Status
-║ │ 🔸 *%sstatusreact* - Like Status
-║ │ 🔸 *%saddstatus* - Add Target
-║ │ 🔸 *%sdelstatus* - Del Target
-║ │ 🔸 *%sliststatus* - List Target
-║ │ 🔸 *%sreadallstatus* - Read All
-║ │ 🔸 *%santidelete* - set/on/off
-║ │ 🔸 *%slistbots* - Active Bots
-║ ╰───────────────────────╯
-╚══════════════════════╝`,
-		BOT_NAME, OWNER_NAME, currentMode, uptimeStr,
-		p, p, p, p, p, p, p, p, p, p, p, p,
-		p, p, p, p, p, p, p, p,
-		p, p, p, p, p, p, p, p, p, p,
-		p, p, p, p, p, p, p, p, p,
-		p, p, p, p, p, p, p, p, p, p, p, p, p, p,
-		p, p, p, p, p, p, p,
-		p, p, p, p, p, p, p, p, p,
-		p, p, p, p, p,
-		p, p, p, p, p, p, p, p, p, p, p, p)
+%s╚══════════════════════╝`,
+		BOT_NAME, OWNER_NAME, currentMode, uptimeStr, renderMenuSections(p, groupSettings, customMenuSection(botID, v)...))
 
 	// 🔥 رپلائی اور چینل کی معلومات کا سیٹ اپ
 	replyContext := &waProto.ContextInfo{
@@ -1529,6 +1633,28 @@ func getText(m *waProto.Message) string {
 	return ""
 }
 
+// getCommandBody کمانڈ کے بعد والا پورا ٹیکسٹ (نئی لائنز سمیت)
+func getCommandBody(v *events.Message) string {
+	body := strings.TrimSpace(getText(v.Message))
+	idx := strings.IndexAny(body, " \n")
+	if idx < 0 { return "" }
+	return strings.TrimSpace(body[idx+1:])
+}
+
+// getContextInfo میسج کی کسی بھی قسم سے ContextInfo نکالتا ہے
+func getContextInfo(m *waProto.Message) *waProto.ContextInfo {
+	if m == nil { return nil }
+	if m.ExtendedTextMessage != nil { return m.ExtendedTextMessage.ContextInfo }
+	if m.ImageMessage != nil { return m.ImageMessage.ContextInfo }
+	if m.VideoMessage != nil { return m.VideoMessage.ContextInfo }
+	if m.AudioMessage != nil { return m.AudioMessage.ContextInfo }
+	if m.StickerMessage != nil { return m.StickerMessage.ContextInfo }
+	if m.DocumentMessage != nil { return m.DocumentMessage.ContextInfo }
+	if m.ContactMessage != nil { return m.ContactMessage.ContextInfo }
+	if m.LocationMessage != nil { return m.LocationMessage.ContextInfo }
+	return nil
+}
+
 func handleSessionDelete(client *whatsmeow.Client, v *events.Message, args []string) {
 	if !isOwner(client, v.Info.Sender) {
 		replyMessage(client, v, "╔═══════════════════╗\n║ 👑 OWNER ONLY      \n╠═══════════════════╣\n║ You don't have    \n║ permission.       \n╚═══════════════════╝")
@@ -1544,6 +1670,9 @@ func handleSessionDelete(client *whatsmeow.Client, v *events.Message, args []str
 		replyMessage(client, v, "❌ Invalid format.")
 		return
 	}
+	if !confirmAction(client, v, "sd", "Delete session "+targetNumber+"?") {
+		return
+	}
 	clientsMutex.Lock()
 	if targetClient, exists := activeClients[getCleanID(targetNumber)]; exists {
 		targetClient.Disconnect()
@@ -1591,4 +1720,13 @@ func WaitForUserReply(senderID string, timeout time.Duration) (string, bool) {
 		replyMutex.Unlock()
 		return "", false // ❌ Timeout (ٹائم آؤٹ ہو گیا)
 	}
+}
+
+// 🕒 صرف اسی چیٹ سے آنے والا جواب قبول (دوسری چیٹ کا میسج عام طرح چلتا ہے)
+func chatReplyKey(chat types.JID, senderID string) string {
+	return chat.String() + "|" + senderID
+}
+
+func WaitForChatReply(chat types.JID, senderID string, timeout time.Duration) (string, bool) {
+	return WaitForUserReply(chatReplyKey(chat, senderID), timeout)
 }
\ No newline at end of file
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ✅ TWO-STEP CONFIRMATION
+// Destructive commands reply with a summary and a 4-digit code; the action
+// runs only if the same user sends the code back in the same chat before
+// the timeout.
+// Which actions need it is per bot: confirm_cmds:<bot> (Redis set), falling
+// back to defaultConfirmActions until the owner changes it. Only actions
+// that call confirmAction can be listed; bulk kick always asks.
+
+const confirmTimeout = 60 * time.Second
+
+var defaultConfirmActions = []string{"sd", "group revoke", "kick bulk", "mode private"}
+
+// confirmableActions ہر وہ ایکشن جس پر confirmAction لگا ہے
+var confirmableActions = map[string]bool{
+	"sd": true, "group revoke": true, "kick bulk": true, "mode private": true,
+}
+
+// alwaysConfirm لسٹ سے ہٹایا نہیں جا سکتا
+var alwaysConfirm = map[string]bool{"kick bulk": true}
+
+func confirmKey(botID string) string { return "confirm_cmds:" + botID }
+
+// confirmActions بوٹ کی موجودہ لسٹ (ڈیفالٹ اگر کبھی سیٹ نہ ہوئی)
+func confirmActions(botID string) []string {
+	if rdb == nil {
+		return defaultConfirmActions
+	}
+	if n, _ := rdb.Exists(ctx, confirmKey(botID)).Result(); n == 0 {
+		return defaultConfirmActions
+	}
+	list, _ := rdb.SMembers(ctx, confirmKey(botID)).Result()
+	sort.Strings(list)
+	return list
+}
+
+func needsConfirm(botID, action string) bool {
+	if alwaysConfirm[action] {
+		return true
+	}
+	for _, a := range confirmActions(botID) {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// confirmAction ضرورت ہو تو کوڈ مانگتا ہے؛ true = ایکشن چلائیں
+func confirmAction(client *whatsmeow.Client, v *events.Message, action, summary string) bool {
+	botID := getCleanID(client.Store.ID.User)
+	if !needsConfirm(botID, action) {
+		return true
+	}
+
+	code := strconv.Itoa(1000 + rand.Intn(9000))
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ ⚠️ CONFIRM
+╠════════════════╣
+║ %s
+╠════════════════╣
+║ Reply *%s* within
+║ %ds to continue
+╚════════════════╝`, summary, code, int(confirmTimeout.Seconds())))
+
+	reply, ok := WaitForChatReply(v.Info.Chat, v.Info.Sender.ToNonAD().String(), confirmTimeout)
+	if !ok {
+		replyMessage(client, v, "⌛ Confirmation expired. Nothing was changed.")
+		return false
+	}
+	if strings.TrimSpace(reply) != code {
+		replyMessage(client, v, "❌ Wrong code. Cancelled.")
+		return false
+	}
+	return true
+}
+
+// ---------------------------------------------------------
+// ⚙️ COMMAND: .confirm [add|del <action>] [reset]
+// ---------------------------------------------------------
+func handleConfirmCmd(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Owner Only!")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Redis not connected.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	key := confirmKey(botID)
+
+	sub, action := "", ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+		action = strings.ToLower(strings.Join(args[1:], " "))
+	}
+
+	switch sub {
+	case "add", "del", "remove":
+		if action == "" {
+			replyMessage(client, v, "⚠️ Usage: .confirm add|del <action>\nExample: .confirm add group revoke")
+			return
+		}
+		if !confirmableActions[action] {
+			names := make([]string, 0, len(confirmableActions))
+			for a := range confirmableActions {
+				names = append(names, a)
+			}
+			sort.Strings(names)
+			replyMessage(client, v, "❌ *"+action+"* has no confirmation step.\nAvailable: "+strings.Join(names, ", "))
+			return
+		}
+		if sub != "add" && alwaysConfirm[action] {
+			replyMessage(client, v, "❌ *"+action+"* always needs confirmation.")
+			return
+		}
+		// پہلی تبدیلی پر ڈیفالٹ لسٹ کو محفوظ کر دیں
+		if n, _ := rdb.Exists(ctx, key).Result(); n == 0 {
+			for _, a := range defaultConfirmActions {
+				rdb.SAdd(ctx, key, a)
+			}
+		}
+		if sub == "add" {
+			rdb.SAdd(ctx, key, action)
+		} else {
+			rdb.SRem(ctx, key, action)
+			// خالی سیٹ Redis میں نہیں رہتا، اس لیے ایک نشان رکھیں
+			rdb.SAdd(ctx, key, "-")
+		}
+		logModAction(client, types.EmptyJID, v.Info.Sender, types.EmptyJID, "confirm "+sub, action)
+
+	case "reset":
+		rdb.Del(ctx, key)
+		logModAction(client, types.EmptyJID, v.Info.Sender, types.EmptyJID, "confirm reset", "")
+	}
+
+	var list []string
+	for _, a := range confirmActions(botID) {
+		if a != "-" && !alwaysConfirm[a] {
+			list = append(list, "🔸 "+a)
+		}
+	}
+	for a := range alwaysConfirm {
+		list = append(list, "🔒 "+a+" (always)")
+	}
+	if len(list) == 0 {
+		list = []string{"None"}
+	}
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ ✅ CONFIRMATION
+╠════════════════╣
+║ %s
+╠════════════════╣
+║ .confirm add <action>
+║ .confirm del <action>
+║ .confirm reset
+╚════════════════╝`, strings.Join(list, "\n║ ")))
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 🧩 CUSTOM COMMANDS
+// .addcmd <name> <template> adds a text command without touching Go code.
+// In a group it is saved for that group (admins); in DM, or with
+// ".addcmd global ...", it is saved for the whole bot (owner only).
+//   customcmd:<bot>:<chat>    group commands (hash name -> JSON)
+//   customcmd:<bot>:global    bot-wide commands
+// Built-in commands always win: custom ones only run from the dispatcher's
+// default case, after the usual mode/role/disabled checks.
+// Template: {sender} {name} {args} {group} {date} {time} and {a|b|c} (random pick)
+
+const (
+	customCategory    = "custom"
+	customCmdGlobal   = "global"
+	customCmdCooldown = 5 * time.Second
+	maxCustomCommands = 50
+)
+
+type CustomCommand struct {
+	Name     string `json:"name"`
+	Template string `json:"template"`
+	By       string `json:"by"`
+	Time     int64  `json:"time"`
+	Scope    string `json:"-"` // chat JID یا global
+}
+
+var customChoiceRe = regexp.MustCompile(`\{([^{}]*\|[^{}]*)\}`)
+
+func customCmdKey(botID, scope string) string {
+	return "customcmd:" + botID + ":" + scope
+}
+
+// builtinCommands processMessage کے سوئچ کے تمام case نام (چھپی کمانڈز اور متبادل سمیت)؛
+// TestBuiltinCommandsMatchDispatcher اسے سوئچ کے ساتھ ملا کر رکھتا ہے
+var builtinCommands = func() map[string]bool {
+	m := make(map[string]bool)
+	for _, c := range []string{
+		"9gag", "add", "addcmd", "addstatus", "afk", "ai", "alwaysonline",
+		"antibug", "antidelete", "antilink", "antipic", "antisticker", "antivideo",
+		"apple", "applemusic", "ar", "archive", "ask", "autoai", "autoreact",
+		"autoread", "autoresponder", "autostatus", "ban", "bandcamp", "bilibili",
+		"bitchute", "btn", "clear", "confirm", "dailymotion", "dashboard", "data",
+		"deezer", "del", "delcmd", "delete", "delstatus", "demote", "disable",
+		"disabled", "dl", "dm", "douyin", "download", "draw", "enable", "facebook",
+		"fancy", "fb", "flickr", "get", "giphy", "git", "github", "google", "gpt",
+		"group", "hd", "help", "hidetag", "id", "ifunny", "ig", "imagine", "img",
+		"imgur", "inactive", "insta", "instagram", "kick", "kwai", "list",
+		"listbots", "liststatus", "lockinfo", "mega", "menu", "mixcloud", "mode",
+		"modlog", "movie", "mute", "mystats", "napster", "net", "network", "notes",
+		"owner", "pin", "ping", "pinterest", "poll", "pollclose", "pollresult",
+		"promote", "rbg", "reactmod", "readallstatus", "reddit", "remini",
+		"removebg", "report", "reportto", "requests", "role", "roles", "rules",
+		"rumble", "s", "save", "sc", "screenshot", "sd", "search", "send", "server",
+		"setgoodbye", "setprefix", "setrules", "setvoice", "setwelcome", "slowmode",
+		"snap", "snapchat", "soundcloud", "speed", "speedtest", "spotify", "ss",
+		"stats", "status", "statusreact", "steam", "sticker", "style", "tagall",
+		"tcs", "ted", "testgoodbye", "testwelcome", "threads", "tidal", "tiktok",
+		"togif", "toimg", "topactive", "toptt", "tourl", "tovideo", "tr",
+		"translate", "tt", "tw", "twitch", "twitter", "unban", "undo", "unmute",
+		"upscale", "vimeo", "voice", "vv", "warn", "weather", "wel", "welcome", "x",
+		"youtube", "yt", "yta", "ytmp3", "ytmp4", "yts", "ytv",
+	} {
+		m[c] = true
+	}
+	return m
+}()
+
+// isBuiltinCommand ڈسپیچر میں موجود کمانڈ
+func isBuiltinCommand(name string) bool {
+	return builtinCommands[name]
+}
+
+// customScopes گروپ پہلے، پھر بوٹ لیول
+func customScopes(v *events.Message) []string {
+	if v.Info.IsGroup {
+		return []string{v.Info.Chat.String(), customCmdGlobal}
+	}
+	return []string{customCmdGlobal}
+}
+
+func loadCustomCommand(botID string, v *events.Message, name string) (*CustomCommand, bool) {
+	if rdb == nil {
+		return nil, false
+	}
+	for _, scope := range customScopes(v) {
+		raw, err := rdb.HGet(ctx, customCmdKey(botID, scope), name).Result()
+		if err != nil {
+			continue
+		}
+		var c CustomCommand
+		if json.Unmarshal([]byte(raw), &c) == nil {
+			c.Scope = scope
+			return &c, true
+		}
+	}
+	return nil, false
+}
+
+// listCustomCommands اس چیٹ میں دستیاب کمانڈز (گروپ والی بوٹ والی کو چھپا دیتی ہے)
+func listCustomCommands(botID string, v *events.Message) []CustomCommand {
+	if rdb == nil {
+		return nil
+	}
+	seen := make(map[string]bool)
+	var out []CustomCommand
+	for _, scope := range customScopes(v) {
+		all, _ := rdb.HGetAll(ctx, customCmdKey(botID, scope)).Result()
+		for name, raw := range all {
+			var c CustomCommand
+			if seen[name] || json.Unmarshal([]byte(raw), &c) != nil {
+				continue
+			}
+			seen[name] = true
+			c.Scope = scope
+			out = append(out, c)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// customMenuSection مینو کے لیے "custom" سیکشن (کوئی کمانڈ نہ ہو تو خالی)
+func customMenuSection(botID string, v *events.Message) []menuSection {
+	cmds := listCustomCommands(botID, v)
+	if len(cmds) == 0 {
+		return nil
+	}
+	sec := menuSection{Key: customCategory, Header: "╭── 🧩 CUSTOM COMMANDS ─╮"}
+	for _, c := range cmds {
+		desc := "Group"
+		if c.Scope == customCmdGlobal {
+			desc = "Bot"
+		}
+		sec.Items = append(sec.Items, menuItem{c.Name, desc, ""})
+	}
+	return []menuSection{sec}
+}
+
+// renderCustomTemplate متغیرات بھر کر ٹیکسٹ اور mentions واپس کرتا ہے
+func renderCustomTemplate(client *whatsmeow.Client, v *events.Message, tpl string, args []string) (string, []string) {
+	text := customChoiceRe.ReplaceAllStringFunc(tpl, func(m string) string {
+		opts := strings.Split(m[1:len(m)-1], "|")
+		return opts[rand.Intn(len(opts))]
+	})
+
+	var mentions []string
+	if strings.Contains(text, "{sender}") {
+		mentions = append(mentions, v.Info.Sender.ToNonAD().String())
+	}
+	group := ""
+	if v.Info.IsGroup && strings.Contains(text, "{group}") {
+		if info, err := client.GetGroupInfo(context.Background(), v.Info.Chat); err == nil {
+			group = info.Name
+		}
+	}
+	name := v.Info.PushName
+	if name == "" {
+		name = v.Info.Sender.User
+	}
+
+	now := time.Now()
+	text = strings.NewReplacer(
+		"{sender}", "@"+v.Info.Sender.User,
+		"{name}", name,
+		"{args}", strings.Join(args, " "),
+		"{group}", group,
+		"{date}", now.Format("02 Jan 2006"),
+		"{time}", now.Format("15:04"),
+	).Replace(text)
+	return text, mentions
+}
+
+// handleCustomCommand ڈسپیچر کا default؛ نامعلوم کمانڈ ہو تو خاموش
+func handleCustomCommand(client *whatsmeow.Client, v *events.Message, cmd string, args []string) {
+	botID := getCleanID(client.Store.ID.User)
+	c, ok := loadCustomCommand(botID, v, cmd)
+	if !ok {
+		return
+	}
+	if v.Info.IsGroup && !isOwner(client, v.Info.Sender) && isCategoryDisabled(getGroupSettings(botID, v.Info.Chat.String()), customCategory) {
+		return
+	}
+	if groupCooldownLeft(v.Info.Chat, "cc:"+c.Name) > 0 {
+		return
+	}
+
+	text, mentions := renderCustomTemplate(client, v, c.Template, args)
+	_, err := client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text: proto.String(text),
+			ContextInfo: &waProto.ContextInfo{
+				StanzaID:      proto.String(v.Info.ID),
+				Participant:   proto.String(v.Info.Sender.String()),
+				QuotedMessage: v.Message,
+				MentionedJID:  mentions,
+			},
+		},
+	})
+	if err == nil {
+		markGroupCooldown(v.Info.Chat, "cc:"+c.Name, customCmdCooldown)
+	}
+}
+
+// customCmdTarget سکوپ طے کرتا ہے اور اجازت چیک کرتا ہے؛ args سے "global" ہٹا دیتا ہے
+func customCmdTarget(client *whatsmeow.Client, v *events.Message, args []string, cmd string) (string, []string, bool) {
+	global := !v.Info.IsGroup
+	if len(args) > 0 && strings.ToLower(args[0]) == customCmdGlobal {
+		global = true
+		args = args[1:]
+	}
+	if global {
+		if !isOwner(client, v.Info.Sender) {
+			replyMessage(client, v, "❌ Owner Only! (bot-wide commands)")
+			return "", nil, false
+		}
+		return customCmdGlobal, args, true
+	}
+	if !hasGroupPermission(client, v, cmd) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return "", nil, false
+	}
+	return v.Info.Chat.String(), args, true
+}
+
+// ---------------------------------------------------------
+// 🧩 COMMAND: .addcmd [global] <name> <template>
+// ---------------------------------------------------------
+func handleAddCustomCmd(client *whatsmeow.Client, v *events.Message, args []string) {
+	if rdb == nil {
+		replyMessage(client, v, "❌ Redis not connected.")
+		return
+	}
+	scope, args, ok := customCmdTarget(client, v, args, "addcmd")
+	if !ok {
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	p := getPrefix(botID)
+
+	if len(args) == 0 {
+		handleListCustomCmds(client, v)
+		return
+	}
+	name, ok := normalizeNoteName(strings.TrimPrefix(args[0], p))
+	if !ok {
+		replyMessage(client, v, "❌ Name can only use a-z, 0-9, _ and - (max 32).")
+		return
+	}
+	if isBuiltinCommand(name) {
+		replyMessage(client, v, "❌ *"+p+name+"* is a built-in command.")
+		return
+	}
+
+	// نام (اور "global") کے بعد والا پورا ٹیکسٹ، نئی لائنز سمیت
+	body := getCommandBody(v)
+	for skip := 1 + len(strings.Fields(body)) - len(args); skip > 0 && body != ""; skip-- {
+		idx := strings.IndexAny(body, " \n")
+		if idx < 0 {
+			body = ""
+			break
+		}
+		body = strings.TrimSpace(body[idx+1:])
+	}
+	tpl := body
+	if tpl == "" {
+		tpl = quotedText(v)
+	}
+	if tpl == "" {
+		replyMessage(client, v, fmt.Sprintf(`⚠️ Usage: %saddcmd <name> <response>
+Variables: {sender} {name} {args} {group} {date} {time}
+Random: {hi|hello|salam}`, p))
+		return
+	}
+
+	key := customCmdKey(botID, scope)
+	if exists, _ := rdb.HExists(ctx, key, name).Result(); !exists {
+		if n, _ := rdb.HLen(ctx, key).Result(); n >= maxCustomCommands {
+			replyMessage(client, v, fmt.Sprintf("❌ Limit reached (%d). Delete one first.", maxCustomCommands))
+			return
+		}
+	}
+	data, _ := json.Marshal(CustomCommand{
+		Name:     name,
+		Template: tpl,
+		By:       v.Info.Sender.ToNonAD().String(),
+		Time:     time.Now().Unix(),
+	})
+	rdb.HSet(ctx, key, name, data)
+
+	logChat, where := v.Info.Chat, "This group"
+	if scope == customCmdGlobal {
+		logChat, where = types.EmptyJID, "Whole bot"
+	}
+	logModAction(client, logChat, v.Info.Sender, types.EmptyJID, "addcmd", name)
+
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🧩 COMMAND SAVED
+╠════════════════╣
+║ 🔸 %s%s
+║ 📍 %s
+╚════════════════╝`, p, name, where))
+}
+
+// ---------------------------------------------------------
+// 🧩 COMMAND: .delcmd [global] <name>
+// ---------------------------------------------------------
+func handleDelCustomCmd(client *whatsmeow.Client, v *events.Message, args []string) {
+	if rdb == nil {
+		replyMessage(client, v, "❌ Redis not connected.")
+		return
+	}
+	scope, args, ok := customCmdTarget(client, v, args, "delcmd")
+	if !ok {
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	p := getPrefix(botID)
+	if len(args) == 0 {
+		replyMessage(client, v, "⚠️ Usage: "+p+"delcmd <name>")
+		return
+	}
+	name, _ := normalizeNoteName(strings.TrimPrefix(args[0], p))
+	if n, _ := rdb.HDel(ctx, customCmdKey(botID, scope), name).Result(); n == 0 {
+		replyMessage(client, v, "❌ No custom command *"+args[0]+"* here.")
+		return
+	}
+
+	logChat := v.Info.Chat
+	if scope == customCmdGlobal {
+		logChat = types.EmptyJID
+	}
+	logModAction(client, logChat, v.Info.Sender, types.EmptyJID, "delcmd", name)
+	replyMessage(client, v, "🗑️ Command *"+p+name+"* deleted.")
+}
+
+func handleListCustomCmds(client *whatsmeow.Client, v *events.Message) {
+	botID := getCleanID(client.Store.ID.User)
+	p := getPrefix(botID)
+
+	var lines []string
+	for _, c := range listCustomCommands(botID, v) {
+		where := "group"
+		if c.Scope == customCmdGlobal {
+			where = "bot"
+		}
+		lines = append(lines, fmt.Sprintf("🔸 %s%s (%s)", p, c.Name, where))
+	}
+	if len(lines) == 0 {
+		lines = []string{"No custom commands"}
+	}
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🧩 CUSTOM COMMANDS
+╠════════════════╣
+║ %s
+╠════════════════╣
+║ %saddcmd hi {hi|hello} {sender}!
+║ %saddcmd global site https://...
+║ %sdelcmd hi
+╚════════════════╝`, strings.Join(lines, "\n║ "), p, p, p))
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+)
+
+// 🧪 Custom commands must never shadow a dispatcher command, so the reserved
+// list has to match the case labels of the `switch cmd` in processMessage.
+
+func dispatcherCaseLabels(t *testing.T) map[string]bool {
+	t.Helper()
+	f, err := parser.ParseFile(token.NewFileSet(), "commands.go", nil, 0)
+	if err != nil {
+		t.Fatalf("parse commands.go: %v", err)
+	}
+
+	labels := make(map[string]bool)
+	ast.Inspect(f, func(n ast.Node) bool {
+		fn, ok := n.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != "processMessage" {
+			return true
+		}
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			sw, ok := n.(*ast.SwitchStmt)
+			if !ok {
+				return true
+			}
+			if tag, ok := sw.Tag.(*ast.Ident); !ok || tag.Name != "cmd" {
+				return true
+			}
+			for _, stmt := range sw.Body.List {
+				for _, expr := range stmt.(*ast.CaseClause).List {
+					if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+						name, _ := strconv.Unquote(lit.Value)
+						labels[name] = true
+					}
+				}
+			}
+			return false
+		})
+		return false
+	})
+	if len(labels) == 0 {
+		t.Fatal("no `switch cmd` found in processMessage")
+	}
+	return labels
+}
+
+func TestBuiltinCommandsMatchDispatcher(t *testing.T) {
+	labels := dispatcherCaseLabels(t)
+	for name := range labels {
+		if !isBuiltinCommand(name) {
+			t.Errorf("dispatcher command %q is missing from builtinCommands", name)
+		}
+	}
+	for name := range builtinCommands {
+		if !labels[name] {
+			t.Errorf("builtinCommands has %q, which the dispatcher does not handle", name)
+		}
+	}
+}
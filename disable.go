@@ -0,0 +1,263 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// 🚫 PER-GROUP COMMAND TOGGLES
+// .disable <command|category> / .enable ... / .disabled
+// Stored as canonical (menu) names in GroupSettings.DisabledCmds and
+// category keys in GroupSettings.DisabledCats.
+
+// commandAliases ڈسپیچر کے متبادل نام → مینو والا نام
+var commandAliases = map[string]string{
+	"wel": "welcome", "delete": "del", "s": "sticker", "translate": "tr",
+	"ytmp4": "yt", "ytmp3": "yt", "ytv": "yt", "yta": "yt", "youtube": "yt",
+	"facebook": "fb", "insta": "ig", "instagram": "ig", "tiktok": "tt",
+	"x": "tw", "twitter": "tw", "pinterest": "pin", "snapchat": "snap",
+	"dailymotion": "dm", "soundcloud": "sc", "applemusic": "apple",
+	"server": "stats", "dashboard": "stats", "speedtest": "speed", "screenshot": "ss",
+	"ask": "ai", "imagine": "img", "draw": "img", "search": "google",
+	"upscale": "remini", "hd": "remini", "rbg": "removebg", "style": "fancy",
+	"voice": "toptt", "github": "git", "dl": "mega", "download": "mega",
+	"roles": "role", "help": "menu", "list": "menu", "net": "network",
+	"ar": "autoresponder",
+}
+
+// categoryGroups ایک نام سے کئی سیکشنز
+var categoryGroups = map[string][]string{
+	"downloader": {"movie", "music", "social"},
+}
+
+// protectedCommands جو کبھی بند نہیں ہو سکتیں
+var protectedCommands = map[string]bool{
+	"enable": true, "disable": true, "disabled": true, "menu": true, "mode": true,
+}
+
+func canonicalCommand(cmd string) string {
+	cmd = strings.ToLower(strings.TrimPrefix(cmd, "."))
+	if c, ok := commandAliases[cmd]; ok {
+		return c
+	}
+	return cmd
+}
+
+// commandCategory مینو ٹیبل سے کمانڈ کی کیٹیگری
+func commandCategory(cmd string) string {
+	for _, sec := range menuSections {
+		for _, it := range sec.Items {
+			if it.Cmd == cmd {
+				return it.category(sec)
+			}
+		}
+	}
+	return ""
+}
+
+func isCategory(name string) bool {
+	if _, ok := categoryGroups[name]; ok || name == customCategory {
+		return true
+	}
+	for _, sec := range menuSections {
+		if sec.Key == name {
+			return true
+		}
+		for _, it := range sec.Items {
+			if it.Cat == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isCommandDisabled(s *GroupSettings, cmd string) bool {
+	if len(s.DisabledCmds) == 0 && len(s.DisabledCats) == 0 {
+		return false
+	}
+	cmd = canonicalCommand(cmd)
+	if protectedCommands[cmd] {
+		return false
+	}
+	for _, c := range s.DisabledCmds {
+		if c == cmd {
+			return true
+		}
+	}
+
+	return isCategoryDisabled(s, commandCategory(cmd))
+}
+
+// isCategoryDisabled سیکشن خود یا اس کا گروپ (مثلاً downloader) بند ہے
+func isCategoryDisabled(s *GroupSettings, cat string) bool {
+	if cat == "" {
+		return false
+	}
+	for _, c := range s.DisabledCats {
+		if c == cat {
+			return true
+		}
+		for _, sub := range categoryGroups[c] {
+			if sub == cat {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func toggleList(list []string, item string, add bool) ([]string, bool) {
+	for i, x := range list {
+		if x == item {
+			if add {
+				return list, false
+			}
+			// نئی سلائس: شیئرڈ سیٹنگز کی بیکنگ ارے کو نہ چھیڑیں
+			out := make([]string, 0, len(list)-1)
+			out = append(out, list[:i]...)
+			return append(out, list[i+1:]...), true
+		}
+	}
+	if add {
+		out := make([]string, 0, len(list)+1)
+		out = append(out, list...)
+		return append(out, item), true
+	}
+	return list, false
+}
+
+// isToggleableCommand ڈسپیچر کی کمانڈ (یا اس کا مینو نام) یا اس چیٹ کی کسٹم کمانڈ
+func isToggleableCommand(botID string, v *events.Message, name, canon string) bool {
+	if isBuiltinCommand(name) || isBuiltinCommand(canon) {
+		return true
+	}
+	_, ok := loadCustomCommand(botID, v, name)
+	return ok
+}
+
+// ---------------------------------------------------------
+// 🚫 COMMANDS: .disable / .enable <command|category> ...
+// ---------------------------------------------------------
+func handleToggleCommand(client *whatsmeow.Client, v *events.Message, args []string, disable bool) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+	if len(args) == 0 {
+		handleDisabledList(client, v)
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	var done, skipped, unknown []string
+	for _, a := range args {
+		name := strings.ToLower(strings.TrimPrefix(a, "."))
+		changed := false
+		switch {
+		case isCategory(name):
+			s.DisabledCats, changed = toggleList(s.DisabledCats, name, disable)
+			name = "[" + name + "]"
+		case protectedCommands[canonicalCommand(name)]:
+			skipped = append(skipped, name+" (protected)")
+			continue
+		default:
+			canon := canonicalCommand(name)
+			// پرانی اندراج ہٹانے کی اجازت، نیا نام صرف اصل یا کسٹم کمانڈ کا
+			if !isToggleableCommand(botID, v, name, canon) && !(!disable && slices.Contains(s.DisabledCmds, canon)) {
+				unknown = append(unknown, name)
+				continue
+			}
+			name = canon
+			s.DisabledCmds, changed = toggleList(s.DisabledCmds, name, disable)
+		}
+		if changed {
+			done = append(done, name)
+		} else {
+			skipped = append(skipped, name)
+		}
+	}
+	saveGroupSettings(botID, s)
+	if len(done) > 0 {
+		action := "enable"
+		if disable {
+			action = "disable"
+		}
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, action, strings.Join(done, ", "))
+	}
+
+	title, icon := "ENABLED", "✅"
+	if disable {
+		title, icon = "DISABLED", "🚫"
+	}
+	msg := fmt.Sprintf(`╔════════════════╗
+║ %s %s
+╠════════════════╣
+║ %s`, icon, title, strings.Join(done, ", "))
+	if len(done) == 0 {
+		msg = fmt.Sprintf(`╔════════════════╗
+║ %s %s
+╠════════════════╣
+║ Nothing changed`, icon, title)
+	}
+	if len(skipped) > 0 {
+		msg += "\n║ ⏭️ Skipped: " + strings.Join(skipped, ", ")
+	}
+	if len(unknown) > 0 {
+		msg += "\n║ ❓ Skipped (unknown): " + strings.Join(unknown, ", ")
+	}
+	msg += "\n╚════════════════╝"
+	replyMessage(client, v, msg)
+}
+
+func handleDisabledList(client *whatsmeow.Client, v *events.Message) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	cmds := append([]string(nil), s.DisabledCmds...)
+	cats := append([]string(nil), s.DisabledCats...)
+	sort.Strings(cmds)
+	sort.Strings(cats)
+
+	none := func(list []string) string {
+		if len(list) == 0 {
+			return "None"
+		}
+		return strings.Join(list, ", ")
+	}
+
+	var keys []string
+	for _, sec := range menuSections {
+		keys = append(keys, sec.Key)
+	}
+	keys = append(keys, "ai", "downloader", customCategory)
+
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🚫 DISABLED HERE
+╠════════════════╣
+║ 📂 Categories: %s
+║ 🔸 Commands: %s
+╠════════════════╣
+║ .disable ai
+║ .disable downloader
+║ .enable tt sticker
+╠════════════════╣
+║ 📂 %s
+╚════════════════╝`, none(cats), none(cmds), strings.Join(keys, ", ")))
+}
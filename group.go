@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"go.mau.fi/whatsmeow"
 	"go.mau.fi/whatsmeow/types"
@@ -13,6 +14,17 @@ import (
 )
 
 func handleKick(client *whatsmeow.Client, v *events.Message, args []string) {
+	// رپورٹ پر ریپلائی (DM یا موڈریشن گروپ): ٹارگٹ رپورٹ والا ممبر ہے، بوٹ نہیں
+	if handleReportAction(client, v, "kick") {
+		return
+	}
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "inactive", "prefix", "list":
+			handleBulkKick(client, v, args)
+			return
+		}
+	}
 	groupAction(client, v, args, "remove")
 }
 
@@ -44,29 +56,136 @@ func handleAdd(client *whatsmeow.Client, v *events.Message, args []string) {
 ║ ⚠️ INVALID
 ╠════════════════
 ║ Usage:
-║ .add <number>
+║ .add <number> ...
 ║
 ║ Example:
-║ .add 92300xxx
+║ .add 92300xxx 92301xxx
 ╚════════════════`
 		replyMessage(client, v, msg)
 		return
 	}
 
-	num := strings.ReplaceAll(args[0], "+", "")
-	jid, _ := types.ParseJID(num + "@s.whatsapp.net")
-	client.UpdateGroupParticipants(context.Background(), v.Info.Chat, []types.JID{jid}, whatsmeow.ParticipantChangeAdd)
+	// کئی نمبرز: .add 92300xxx 92301xxx یا کوما سے الگ
+	var jids []types.JID
+	seen := make(map[string]bool)
+	for _, a := range args {
+		for _, part := range strings.Split(a, ",") {
+			num := strings.Map(func(r rune) rune {
+				if r >= '0' && r <= '9' {
+					return r
+				}
+				return -1
+			}, part)
+			if len(num) < 7 || seen[num] {
+				continue
+			}
+			seen[num] = true
+			jids = append(jids, types.NewJID(num, types.DefaultUserServer))
+		}
+	}
+	if len(jids) == 0 {
+		replyMessage(client, v, "⚠️ No valid numbers found.\nExample: .add 92300xxx 92301xxx")
+		return
+	}
 
+	results, err := client.UpdateGroupParticipants(context.Background(), v.Info.Chat, jids, whatsmeow.ParticipantChangeAdd)
+	if err != nil {
+		groupResult(client, v, "ADD", "", err)
+		return
+	}
+
+	var added, invited, failed []string
+	for _, r := range results {
+		num := r.JID.User
+		if !r.PhoneNumber.IsEmpty() {
+			num = r.PhoneNumber.User
+		}
+		switch r.Error {
+		case 0:
+			added = append(added, "+"+num)
+			logModAction(client, v.Info.Chat, v.Info.Sender, r.JID, "add", "")
+		case 403:
+			// پرائیویسی کی وجہ سے ڈائریکٹ ایڈ نہیں ہو سکا، انوائٹ بھیجیں
+			if err := sendAddInvite(client, v.Info.Chat, r); err == nil {
+				invited = append(invited, "+"+num)
+				logModAction(client, v.Info.Chat, v.Info.Sender, r.JID, "invite", "privacy blocked add")
+			} else {
+				failed = append(failed, "+"+num+" (invite failed)")
+			}
+		default:
+			failed = append(failed, "+"+num+" ("+addErrorReason(r.Error)+")")
+		}
+	}
+
+	list := func(items []string) string {
+		if len(items) == 0 {
+			return "-"
+		}
+		return strings.Join(items, "\n║ ")
+	}
 	msg := fmt.Sprintf(`╔════════════════╗
-║ ✅ ADDED
+║ ➕ ADD RESULT
 ╠════════════════
-║ Number: %s
-║ Added to group
-╚════════════════`, args[0])
+║ ✅ Added: %d
+║ %s
+║ 📩 Invited: %d
+║ %s
+║ ❌ Failed: %d
+║ %s
+╚════════════════`, len(added), list(added), len(invited), list(invited), len(failed), list(failed))
 
 	replyMessage(client, v, msg)
 }
 
+func addErrorReason(code int) string {
+	switch code {
+	case 408:
+		return "left recently"
+	case 409:
+		return "already in group"
+	case 401:
+		return "blocked the bot"
+	}
+	return fmt.Sprintf("error %d", code)
+}
+
+// sendAddInvite ایڈ ریجیکٹ ہونے پر نیٹو گروپ انوائٹ میسج بھیجتا ہے
+func sendAddInvite(client *whatsmeow.Client, chat types.JID, p types.GroupParticipant) error {
+	code := ""
+	var expiration int64
+	if p.AddRequest != nil {
+		code = p.AddRequest.Code
+		expiration = p.AddRequest.Expiration.Unix()
+	} else {
+		link, err := client.GetGroupInviteLink(context.Background(), chat, false)
+		if err != nil {
+			return err
+		}
+		code = strings.TrimPrefix(link, "https://chat.whatsapp.com/")
+		expiration = time.Now().Add(3 * 24 * time.Hour).Unix()
+	}
+
+	groupName := "Group"
+	if info, err := client.GetGroupInfo(context.Background(), chat); err == nil {
+		groupName = info.Name
+	}
+
+	target := p.JID
+	if !p.PhoneNumber.IsEmpty() {
+		target = p.PhoneNumber
+	}
+	_, err := client.SendMessage(context.Background(), target, &waProto.Message{
+		GroupInviteMessage: &waProto.GroupInviteMessage{
+			GroupJID:         proto.String(chat.String()),
+			InviteCode:       proto.String(code),
+			InviteExpiration: proto.Int64(expiration),
+			GroupName:        proto.String(groupName),
+			Caption:          proto.String("You couldn't be added directly. Tap to join " + groupName),
+		},
+	})
+	return err
+}
+
 func handlePromote(client *whatsmeow.Client, v *events.Message, args []string) {
 	groupAction(client, v, args, "promote")
 }
@@ -88,7 +207,7 @@ func handleTagAll(client *whatsmeow.Client, v *events.Message, args []string) {
 		return
 	}
 
-	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+	if !hasGroupPermission(client, v, "tagall") {
 		msg := `╔════════════════╗
 ║ ❌ DENIED
 ╠════════════════
@@ -98,34 +217,84 @@ func handleTagAll(client *whatsmeow.Client, v *events.Message, args []string) {
 		return
 	}
 
-	info, _ := client.GetGroupInfo(context.Background(), v.Info.Chat)
-	mentions := []string{}
-	out := "╔════════════════╗\n"
-	out += "║ 📣 TAG ALL\n"
-	out += "╠════════════════\n"
+	if !tagAllowed(client, v, "tagall") {
+		return
+	}
 
-	if len(args) > 0 {
-		out += "║ 💬 " + strings.Join(args, " ") + "\n"
+	filter, rest := parseTagFilter(args)
+	info, err := client.GetGroupInfo(context.Background(), v.Info.Chat)
+	if err != nil {
+		replyMessage(client, v, "❌ Failed to get group info: "+err.Error())
+		return
+	}
+	targets := filterTagTargets(info.Participants, filter)
+	if len(targets) == 0 {
+		replyMessage(client, v, "⚠️ No "+filter+" to tag.")
+		return
 	}
 
-	for _, p := range info.Participants {
-		mentions = append(mentions, p.JID.String())
-		out += "║ @" + p.JID.User + "\n"
+	title := "📣 TAG ALL"
+	switch filter {
+	case "admins":
+		title = "👮 TAG ADMINS"
+	case "members":
+		title = "👥 TAG MEMBERS"
 	}
 
-	out += fmt.Sprintf("║ 👥 Total: %d\n", len(info.Participants))
-	out += "╚════════════════"
+	chunks := chunkJIDs(targets, tagChunkSize)
+	sent := false
+	for i, chunk := range chunks {
+		out := "╔════════════════╗\n"
+		out += "║ " + title
+		if len(chunks) > 1 {
+			out += fmt.Sprintf(" (%d/%d)", i+1, len(chunks))
+		}
+		out += "\n╠════════════════\n"
 
-	client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
-		ExtendedTextMessage: &waProto.ExtendedTextMessage{
-			Text: proto.String(out),
-			ContextInfo: &waProto.ContextInfo{
-				MentionedJID: mentions,
-				StanzaID:     proto.String(v.Info.ID),
-				Participant:  proto.String(v.Info.Sender.String()),
+		if len(rest) > 0 && i == 0 {
+			out += "║ 💬 " + strings.Join(rest, " ") + "\n"
+		}
+
+		for _, j := range chunk {
+			out += "║ @" + j.User + "\n"
+		}
+
+		if i == len(chunks)-1 {
+			out += fmt.Sprintf("║ 👥 Total: %d\n", len(targets))
+		}
+		out += "╚════════════════"
+
+		if i > 0 {
+			time.Sleep(tagChunkGap)
+		}
+		_, err := client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
+			ExtendedTextMessage: &waProto.ExtendedTextMessage{
+				Text:        proto.String(out),
+				ContextInfo: tagContext(v, jidStrings(chunk), true),
 			},
-		},
-	})
+		})
+		sent = sent || err == nil
+	}
+	tagSent(client, v, "tagall", sent)
+}
+
+// tagAllowed ماس ٹیگ کا کول ڈاؤن (اونر پر لاگو نہیں)
+func tagAllowed(client *whatsmeow.Client, v *events.Message, action string) bool {
+	if isOwner(client, v.Info.Sender) {
+		return true
+	}
+	if left := groupCooldownLeft(v.Info.Chat, action); left > 0 {
+		replyMessage(client, v, fmt.Sprintf("⏳ Please wait %ds before using .%s again.", int(left.Seconds())+1, action))
+		return false
+	}
+	return true
+}
+
+// tagSent کم از کم ایک حصہ پہنچ گیا ہو تو کول ڈاؤن شروع
+func tagSent(client *whatsmeow.Client, v *events.Message, action string, sent bool) {
+	if sent && !isOwner(client, v.Info.Sender) {
+		markGroupCooldown(v.Info.Chat, action, tagCooldown)
+	}
 }
 
 func handleHideTag(client *whatsmeow.Client, v *events.Message, args []string) {
@@ -141,7 +310,7 @@ func handleHideTag(client *whatsmeow.Client, v *events.Message, args []string) {
 		return
 	}
 
-	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+	if !hasGroupPermission(client, v, "hidetag") {
 		msg := `╔════════════════╗
 ║ ❌ DENIED
 ╠════════════════
@@ -151,26 +320,38 @@ func handleHideTag(client *whatsmeow.Client, v *events.Message, args []string) {
 		return
 	}
 
-	info, _ := client.GetGroupInfo(context.Background(), v.Info.Chat)
-	mentions := []string{}
-	text := strings.Join(args, " ")
+	if !tagAllowed(client, v, "hidetag") {
+		return
+	}
 
-	if text == "" {
-		text = "🔔 Hidden Tag"
+	filter, rest := parseTagFilter(args)
+	info, err := client.GetGroupInfo(context.Background(), v.Info.Chat)
+	if err != nil {
+		replyMessage(client, v, "❌ Failed to get group info: "+err.Error())
+		return
 	}
+	targets := filterTagTargets(info.Participants, filter)
+	text := strings.Join(rest, " ")
 
-	for _, p := range info.Participants {
-		mentions = append(mentions, p.JID.String())
+	if text == "" {
+		text = "🔔 Hidden Tag"
 	}
 
-	client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
-		ExtendedTextMessage: &waProto.ExtendedTextMessage{
-			Text: proto.String(text),
-			ContextInfo: &waProto.ContextInfo{
-				MentionedJID: mentions,
+	// ہر حصہ وہی ٹیکسٹ، مینشنز بٹی ہوئی (تاکہ سب کو نوٹیفکیشن ملے)
+	sent := false
+	for i, chunk := range chunkJIDs(targets, tagChunkSize) {
+		if i > 0 {
+			time.Sleep(tagChunkGap)
+		}
+		_, err := client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
+			ExtendedTextMessage: &waProto.ExtendedTextMessage{
+				Text:        proto.String(text),
+				ContextInfo: tagContext(v, jidStrings(chunk), false),
 			},
-		},
-	})
+		})
+		sent = sent || err == nil
+	}
+	tagSent(client, v, "hidetag", sent)
 }
 
 func handleGroup(client *whatsmeow.Client, v *events.Message, args []string) {
@@ -213,6 +394,20 @@ func handleGroup(client *whatsmeow.Client, v *events.Message, args []string) {
 ║
 ║ 🔄 .group revoke
 ║    Revoke link
+║
+║ ⏰ .group schedule
+║    Night mode
+║
+║ 🌍 .group timezone
+║    Set timezone
+║
+║ 📛 .group name <text>
+║ 📝 .group desc <text>
+║ 🖼️ .group icon (reply)
+║ ✏️ .group lock/unlock
+║ 🛂 .group approval on/off
+║ ⏳ .group ephemeral 24h/7d/off
+║ ℹ️ .group info
 ╚════════════════`
 		replyMessage(client, v, msg)
 		return
@@ -220,7 +415,11 @@ func handleGroup(client *whatsmeow.Client, v *events.Message, args []string) {
 
 	switch strings.ToLower(args[0]) {
 	case "close":
-		client.SetGroupAnnounce(context.Background(), v.Info.Chat, true)
+		if err := client.SetGroupAnnounce(context.Background(), v.Info.Chat, true); err != nil {
+			groupResult(client, v, "CLOSE", "", err)
+			return
+		}
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "group close", "")
 		msg := `╔════════════════╗
 ║ 🔒 CLOSED
 ╠════════════════
@@ -230,7 +429,11 @@ func handleGroup(client *whatsmeow.Client, v *events.Message, args []string) {
 		replyMessage(client, v, msg)
 
 	case "open":
-		client.SetGroupAnnounce(context.Background(), v.Info.Chat, false)
+		if err := client.SetGroupAnnounce(context.Background(), v.Info.Chat, false); err != nil {
+			groupResult(client, v, "OPEN", "", err)
+			return
+		}
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "group open", "")
 		msg := `╔════════════════╗
 ║ 🔓 OPENED
 ╠════════════════
@@ -250,7 +453,14 @@ func handleGroup(client *whatsmeow.Client, v *events.Message, args []string) {
 		replyMessage(client, v, msg)
 
 	case "revoke":
-		client.GetGroupInviteLink(context.Background(), v.Info.Chat, true)
+		if !confirmAction(client, v, "group revoke", "Revoke the invite link?") {
+			return
+		}
+		if _, err := client.GetGroupInviteLink(context.Background(), v.Info.Chat, true); err != nil {
+			groupResult(client, v, "REVOKE", "", err)
+			return
+		}
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "group revoke", "")
 		msg := `╔════════════════╗
 ║ 🔄 REVOKED
 ╠════════════════
@@ -261,13 +471,42 @@ func handleGroup(client *whatsmeow.Client, v *events.Message, args []string) {
 ╚════════════════`
 		replyMessage(client, v, msg)
 
+	case "schedule":
+		handleGroupSchedule(client, v, args[1:])
+
+	case "timezone", "tz":
+		handleGroupTimezone(client, v, args[1:])
+
+	case "name":
+		handleGroupName(client, v)
+
+	case "desc", "description":
+		handleGroupDesc(client, v)
+
+	case "icon", "pic":
+		handleGroupIcon(client, v)
+
+	case "lock":
+		handleGroupLock(client, v, true)
+
+	case "unlock":
+		handleGroupLock(client, v, false)
+
+	case "approval":
+		handleGroupApproval(client, v, args[1:])
+
+	case "ephemeral", "disappear":
+		handleGroupEphemeral(client, v, args[1:])
+
+	case "info":
+		handleGroupInfo(client, v)
+
 	default:
 		msg := `╔════════════════╗
 ║ ❌ INVALID
 ╠════════════════
-║ Use: close,
-║ open, link, or
-║ revoke
+║ Use .group to
+║ see all options
 ╚════════════════`
 		replyMessage(client, v, msg)
 	}
@@ -278,7 +517,7 @@ func handleDelete(client *whatsmeow.Client, v *events.Message) {
 		return
 	}
 
-	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+	if !hasGroupPermission(client, v, "del") {
 		msg := `╔════════════════╗
 ║ ❌ DENIED
 ╠════════════════
@@ -306,6 +545,8 @@ func handleDelete(client *whatsmeow.Client, v *events.Message) {
 	}
 
 	client.RevokeMessage(context.Background(), v.Info.Chat, *ctx.StanzaID)
+	deleted, _ := types.ParseJID(ctx.GetParticipant())
+	logModAction(client, v.Info.Chat, v.Info.Sender, deleted, "delete", "")
 
 	msg := `╔════════════════╗
 ║ 🗑️ DELETED
@@ -315,6 +556,41 @@ func handleDelete(client *whatsmeow.Client, v *events.Message) {
 	replyMessage(client, v, msg)
 }
 
+// getTargetJID نمبر (args)، ریپلائی یا مینشن سے ٹارگٹ یوزر نکالتا ہے
+func getTargetJID(v *events.Message, args []string) (types.JID, error) {
+	if len(args) > 0 {
+		num := strings.TrimSpace(args[0])
+		num = strings.TrimPrefix(num, "@")
+		num = strings.ReplaceAll(num, "+", "")
+		if !strings.Contains(num, "@") {
+			num = num + "@s.whatsapp.net"
+		}
+		return types.ParseJID(num)
+	}
+
+	if ci := getContextInfo(v.Message); ci != nil {
+		if ci.Participant != nil {
+			return types.ParseJID(*ci.Participant)
+		}
+		if len(ci.MentionedJID) > 0 {
+			return types.ParseJID(ci.MentionedJID[0])
+		}
+	}
+	return types.EmptyJID, nil
+}
+
+// groupActions ڈسپیچر کا ایکشن → واٹس ایپ تبدیلی؛ Log وہ نام ہے جو .undo پہچانتا ہے
+var groupActions = map[string]struct {
+	Change whatsmeow.ParticipantChange
+	Log    string
+	Text   string
+	Emoji  string
+}{
+	"remove":  {whatsmeow.ParticipantChangeRemove, "kick", "Kicked", "👢"},
+	"promote": {whatsmeow.ParticipantChangePromote, "promote", "Promoted", "⬆️"},
+	"demote":  {whatsmeow.ParticipantChangeDemote, "demote", "Demoted", "⬇️"},
+}
+
 func groupAction(client *whatsmeow.Client, v *events.Message, args []string, action string) {
 	if !v.Info.IsGroup {
 		msg := `╔════════════════╗
@@ -338,33 +614,15 @@ func groupAction(client *whatsmeow.Client, v *events.Message, args []string, act
 		return
 	}
 
-	var targetJID types.JID
-	if len(args) > 0 {
-		num := strings.TrimSpace(args[0])
-		num = strings.ReplaceAll(num, "+", "")
-		if !strings.Contains(num, "@") {
-			num = num + "@s.whatsapp.net"
-		}
-		jid, err := types.ParseJID(num)
-		if err != nil {
-			msg := `╔════════════════╗
+	targetJID, err := getTargetJID(v, args)
+	if err != nil {
+		msg := `╔════════════════╗
 ║ ❌ INVALID
 ╠════════════════
 ║ Invalid number
 ╚════════════════`
-			replyMessage(client, v, msg)
-			return
-		}
-		targetJID = jid
-	} else if v.Message.ExtendedTextMessage != nil && v.Message.ExtendedTextMessage.ContextInfo != nil {
-		ctx := v.Message.ExtendedTextMessage.ContextInfo
-		if ctx.Participant != nil {
-			jid, _ := types.ParseJID(*ctx.Participant)
-			targetJID = jid
-		} else if len(ctx.MentionedJID) > 0 {
-			jid, _ := types.ParseJID(ctx.MentionedJID[0])
-			targetJID = jid
-		}
+		replyMessage(client, v, msg)
+		return
 	}
 
 	if targetJID.User == "" {
@@ -389,32 +647,23 @@ func groupAction(client *whatsmeow.Client, v *events.Message, args []string, act
 		return
 	}
 
-	var actionText, actionEmoji string
-	var participantChange whatsmeow.ParticipantChange
-
-	switch action {
-	case "remove":
-		participantChange = whatsmeow.ParticipantChangeRemove
-		actionText = "Kicked"
-		actionEmoji = "👢"
-	case "promote":
-		participantChange = whatsmeow.ParticipantChangePromote
-		actionText = "Promoted"
-		actionEmoji = "⬆️"
-	case "demote":
-		participantChange = whatsmeow.ParticipantChangeDemote
-		actionText = "Demoted"
-		actionEmoji = "⬇️"
+	ga := groupActions[action]
+	res, err := client.UpdateGroupParticipants(context.Background(), v.Info.Chat, []types.JID{targetJID}, ga.Change)
+	if err == nil && len(res) > 0 && res[0].Error != 0 {
+		err = fmt.Errorf("WhatsApp refused (code %d)", res[0].Error)
 	}
-
-	client.UpdateGroupParticipants(context.Background(), v.Info.Chat, []types.JID{targetJID}, participantChange)
+	if err != nil {
+		groupResult(client, v, strings.ToUpper(ga.Log), "", err)
+		return
+	}
+	logModAction(client, v.Info.Chat, v.Info.Sender, targetJID, ga.Log, "")
 
 	msg := fmt.Sprintf(`╔════════════════╗
 ║ %s %s
 ╠════════════════
 ║ User: @%s
 ║ ✅ Done
-╚════════════════`, actionEmoji, strings.ToUpper(actionText), targetJID.User)
+╚════════════════`, ga.Emoji, strings.ToUpper(ga.Text), targetJID.User)
 
 	client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
 		ExtendedTextMessage: &waProto.ExtendedTextMessage{
@@ -426,4 +675,117 @@ func groupAction(client *whatsmeow.Client, v *events.Message, args []string, act
 			},
 		},
 	})
-}
\ No newline at end of file
+}
+// applyWarning وارننگ بڑھاتا ہے، 3/3 پر کک کر دیتا ہے
+// autoKick false ہو (وارن کرنے والا رول والا ممبر، ایڈمن نہیں) تو 3/3 پر رک کر ایڈمنز پر چھوڑ دیتا ہے
+func applyWarning(client *whatsmeow.Client, chat, target types.JID, reason string, autoKick bool) (int, bool) {
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, chat.String())
+
+	key := target.ToNonAD().String()
+	warnings := cloneMap(s.Warnings)
+	if warnings[key] < 3 {
+		warnings[key]++
+	}
+	count := warnings[key]
+	s.Warnings = warnings
+
+	kicked := false
+	var kickErr error
+	if count >= 3 && autoKick {
+		res, err := client.UpdateGroupParticipants(context.Background(), chat, []types.JID{target}, whatsmeow.ParticipantChangeRemove)
+		if err == nil && len(res) > 0 && res[0].Error != 0 {
+			err = fmt.Errorf("WhatsApp refused (code %d)", res[0].Error)
+		}
+		kickErr = err
+		if err == nil {
+			warnings = cloneMap(warnings)
+			delete(warnings, key)
+			s.Warnings = warnings
+			kicked = true
+			logModAction(client, chat, types.EmptyJID, target, "kick", "3/3 warnings")
+		}
+	}
+	saveGroupSettings(botID, s)
+
+	if kicked {
+		sendGroupNotice(client, chat, target, fmt.Sprintf(`╔════════════════╗
+║ 🚫 KICKED
+╠════════════════╣
+║ User: @%s
+║ Warning: 3/3
+║ Reason: %s
+╚════════════════╝`, target.User, reason))
+	} else if count >= 3 {
+		next := "An admin can .kick"
+		if kickErr != nil {
+			next = "⚠️ Kick failed: " + explainGroupError(kickErr)
+		}
+		sendGroupNotice(client, chat, target, fmt.Sprintf(`╔════════════════╗
+║ ⚠️ WARNING
+╠════════════════╣
+║ User: @%s
+║ Count: 3/3
+║ Reason: %s
+║ %s
+╚════════════════╝`, target.User, reason, next))
+	} else {
+		sendGroupNotice(client, chat, target, fmt.Sprintf(`╔════════════════╗
+║ ⚠️ WARNING
+╠════════════════╣
+║ User: @%s
+║ Count: %d/3
+║ Reason: %s
+╚════════════════╝`, target.User, count, reason))
+	}
+	return count, kicked
+}
+
+func handleWarn(client *whatsmeow.Client, v *events.Message, args []string) {
+	if handleReportAction(client, v, "warn") {
+		return
+	}
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+
+	if !hasGroupPermission(client, v, "warn") {
+		msg := `╔════════════════╗
+║ ❌ DENIED
+╠════════════════
+║ 🔒 Admin Only
+╚════════════════`
+		replyMessage(client, v, msg)
+		return
+	}
+
+	// پہلا آرگ نمبر/مینشن ہو تو ٹارگٹ، باقی وجہ
+	var target types.JID
+	reason := "Warned by admin"
+	if len(args) > 0 && strings.TrimLeft(strings.TrimPrefix(args[0], "@"), "+0123456789") == "" {
+		target, _ = getTargetJID(v, args[:1])
+		args = args[1:]
+	} else {
+		target, _ = getTargetJID(v, nil)
+	}
+	if len(args) > 0 {
+		reason = strings.Join(args, " ")
+	}
+
+	if target.User == "" {
+		msg := `╔════════════════╗
+║ ⚠️ NO USER
+╠════════════════
+║ Mention or
+║ reply to user
+╚════════════════`
+		replyMessage(client, v, msg)
+		return
+	}
+
+	logModAction(client, v.Info.Chat, v.Info.Sender, target, "warn", reason)
+	// رول والے ممبرز صرف وارن کر سکتے ہیں، 3/3 پر کک ایڈمن کا فیصلہ
+	autoKick := isAdmin(client, v.Info.Chat, v.Info.Sender) || isOwner(client, v.Info.Sender)
+	applyWarning(client, v.Info.Chat, target, reason, autoKick)
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	_ "image/png"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ⚙️ EXTENDED .group SUBCOMMANDS
+// name / desc / icon / lock / unlock / approval / ephemeral / info
+
+// explainGroupError واٹس ایپ کے ایررز کو قابلِ فہم بناتا ہے
+func explainGroupError(err error) string {
+	switch {
+	case errors.Is(err, whatsmeow.ErrIQForbidden), errors.Is(err, whatsmeow.ErrIQNotAuthorized):
+		return "Bot is not an admin here"
+	case errors.Is(err, whatsmeow.ErrIQBadRequest), errors.Is(err, whatsmeow.ErrInvalidDisappearingTimer):
+		return "WhatsApp rejected the value"
+	case errors.Is(err, whatsmeow.ErrIQNotAcceptable):
+		return "Value not accepted (too long or invalid)"
+	case errors.Is(err, whatsmeow.ErrIQRateOverLimit):
+		return "Too many changes, try again later"
+	case errors.Is(err, whatsmeow.ErrNotInGroup):
+		return "Bot is not in this group"
+	}
+	return err.Error()
+}
+
+// groupResult کامیابی یا ایرر کا باکس
+func groupResult(client *whatsmeow.Client, v *events.Message, title, detail string, err error) {
+	if err != nil {
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ ❌ FAILED
+╠════════════════
+║ %s
+║ %s
+╚════════════════`, title, explainGroupError(err)))
+		return
+	}
+	logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "group "+strings.ToLower(title), detail)
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ ✅ %s
+╠════════════════
+║ %s
+╚════════════════`, title, detail))
+}
+
+// subcommandBody ".group name <text>" میں سے <text> (نئی لائنز سمیت)
+func subcommandBody(v *events.Message) string {
+	body := getCommandBody(v)
+	idx := strings.IndexAny(body, " \n")
+	if idx < 0 {
+		return ""
+	}
+	return strings.TrimSpace(body[idx+1:])
+}
+
+// lockInfoDenied Lock Info آن ہو تو نام/تفصیل/تصویر صرف ٹرسٹڈ ایڈمن بدل سکتے ہیں
+func lockInfoDenied(client *whatsmeow.Client, v *events.Message) bool {
+	s := getGroupSettings(getCleanID(client.Store.ID.User), v.Info.Chat.String())
+	if !s.LockInfo || isTrustedChanger(client, s, v.Info.Sender) {
+		return false
+	}
+	replyMessage(client, v, "🔒 Lock Info is on. Only trusted admins can change the group info.")
+	return true
+}
+
+func handleGroupName(client *whatsmeow.Client, v *events.Message) {
+	if lockInfoDenied(client, v) {
+		return
+	}
+	name := subcommandBody(v)
+	if name == "" {
+		replyMessage(client, v, "⚠️ Usage: .group name <text>")
+		return
+	}
+	if len([]rune(name)) > 100 {
+		replyMessage(client, v, "⚠️ Group name can be at most 100 characters.")
+		return
+	}
+	err := client.SetGroupName(context.Background(), v.Info.Chat, name)
+	groupResult(client, v, "NAME CHANGED", name, err)
+}
+
+func handleGroupDesc(client *whatsmeow.Client, v *events.Message) {
+	if lockInfoDenied(client, v) {
+		return
+	}
+	body := subcommandBody(v)
+	if body == "" {
+		body = quotedText(v)
+	}
+	if body == "" {
+		replyMessage(client, v, "⚠️ Usage: .group desc <text> (or reply to a message)\n.group desc clear")
+		return
+	}
+	if strings.ToLower(body) == "clear" {
+		body = ""
+	}
+
+	info, err := client.GetGroupInfo(context.Background(), v.Info.Chat)
+	if err != nil {
+		groupResult(client, v, "DESCRIPTION", "", err)
+		return
+	}
+	err = client.SetGroupTopic(context.Background(), v.Info.Chat, info.TopicID, "", body)
+	detail := "Description updated"
+	if body == "" {
+		detail = "Description cleared"
+	}
+	groupResult(client, v, "DESCRIPTION", detail, err)
+}
+
+func handleGroupIcon(client *whatsmeow.Client, v *events.Message) {
+	if lockInfoDenied(client, v) {
+		return
+	}
+	ci := getContextInfo(v.Message)
+	img := v.Message.GetImageMessage()
+	if img == nil && ci != nil && ci.QuotedMessage != nil {
+		img = ci.QuotedMessage.GetImageMessage()
+	}
+	if img == nil {
+		replyMessage(client, v, "⚠️ Reply to an image with .group icon")
+		return
+	}
+
+	data, err := client.Download(context.Background(), img)
+	if err != nil {
+		groupResult(client, v, "ICON", "", fmt.Errorf("download failed: %w", err))
+		return
+	}
+	jpg, err := squareJPEG(data, 640)
+	if err != nil {
+		groupResult(client, v, "ICON", "", fmt.Errorf("unsupported image: %w", err))
+		return
+	}
+
+	_, err = client.SetGroupPhoto(context.Background(), v.Info.Chat, jpg)
+	groupResult(client, v, "ICON CHANGED", "New group picture set", err)
+}
+
+// squareJPEG تصویر کو درمیان سے مربع کاٹ کر زیادہ سے زیادہ max px کی JPEG بناتا ہے
+func squareJPEG(data []byte, max int) ([]byte, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	b := src.Bounds()
+	side := b.Dx()
+	if b.Dy() < side {
+		side = b.Dy()
+	}
+	x0 := b.Min.X + (b.Dx()-side)/2
+	y0 := b.Min.Y + (b.Dy()-side)/2
+
+	size := side
+	if size > max {
+		size = max
+	}
+
+	// nearest-neighbour resize (بیرونی لائبریری کے بغیر)
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			dst.Set(x, y, src.At(x0+x*side/size, y0+y*side/size))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: 90}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func handleGroupLock(client *whatsmeow.Client, v *events.Message, locked bool) {
+	err := client.SetGroupLocked(context.Background(), v.Info.Chat, locked)
+	if locked {
+		groupResult(client, v, "INFO LOCKED", "Only admins can edit\n║ group info", err)
+	} else {
+		groupResult(client, v, "INFO UNLOCKED", "All members can edit\n║ group info", err)
+	}
+}
+
+func handleGroupApproval(client *whatsmeow.Client, v *events.Message, args []string) {
+	mode := ""
+	if len(args) > 0 {
+		mode = strings.ToLower(args[0])
+	}
+	if mode != "on" && mode != "off" {
+		replyMessage(client, v, "⚠️ Usage: .group approval on|off")
+		return
+	}
+	on := mode == "on"
+	err := client.SetGroupJoinApprovalMode(context.Background(), v.Info.Chat, on)
+	if on {
+		groupResult(client, v, "APPROVAL ON", "New members need\n║ admin approval", err)
+	} else {
+		groupResult(client, v, "APPROVAL OFF", "Anyone with the link\n║ can join", err)
+	}
+}
+
+func handleGroupEphemeral(client *whatsmeow.Client, v *events.Message, args []string) {
+	if len(args) == 0 {
+		replyMessage(client, v, "⚠️ Usage: .group ephemeral 24h|7d|90d|off")
+		return
+	}
+	timer, ok := whatsmeow.ParseDisappearingTimerString(args[0])
+	if !ok {
+		replyMessage(client, v, "⚠️ Usage: .group ephemeral 24h|7d|90d|off")
+		return
+	}
+	err := client.SetDisappearingTimer(context.Background(), v.Info.Chat, timer, time.Now())
+	detail := "Disappearing messages\n║ " + formatTimer(uint32(timer.Seconds()))
+	groupResult(client, v, "EPHEMERAL", detail, err)
+}
+
+func formatTimer(secs uint32) string {
+	switch {
+	case secs == 0:
+		return "OFF"
+	case secs%86400 == 0:
+		return fmt.Sprintf("%d day(s)", secs/86400)
+	case secs%3600 == 0:
+		return fmt.Sprintf("%d hour(s)", secs/3600)
+	}
+	return fmt.Sprintf("%ds", secs)
+}
+
+func onOff(b bool) string {
+	if b {
+		return "ON"
+	}
+	return "OFF"
+}
+
+func handleGroupInfo(client *whatsmeow.Client, v *events.Message) {
+	info, err := client.GetGroupInfo(context.Background(), v.Info.Chat)
+	if err != nil {
+		groupResult(client, v, "GROUP INFO", "", err)
+		return
+	}
+
+	admins := 0
+	for _, p := range info.Participants {
+		if p.IsAdmin || p.IsSuperAdmin {
+			admins++
+		}
+	}
+
+	owner := "Unknown"
+	if !info.OwnerPN.IsEmpty() {
+		owner = "+" + info.OwnerPN.User
+	} else if !info.OwnerJID.IsEmpty() {
+		owner = info.OwnerJID.User
+	}
+	created := "Unknown"
+	if !info.GroupCreated.IsZero() {
+		created = info.GroupCreated.Format("02 Jan 2006")
+	}
+	ephemeral := "OFF"
+	if info.IsEphemeral {
+		ephemeral = formatTimer(info.DisappearingTimer)
+	}
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ ℹ️ GROUP INFO
+╠════════════════
+║ 📛 %s
+║ 📅 Created: %s
+║ 👑 Owner: %s
+║ 👥 Members: %d
+║ 👮 Admins: %d
+║ 🔒 Closed: %s
+║ ✏️ Info locked: %s
+║ 🛂 Approval: %s
+║ ⏳ Ephemeral: %s
+╚════════════════`, info.Name, created, owner, len(info.Participants), admins,
+		onOff(info.IsAnnounce), onOff(info.IsLocked), onOff(info.IsJoinApprovalRequired), ephemeral)
+	replyMessage(client, v, msg)
+}
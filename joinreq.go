@@ -0,0 +1,589 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 🛂 JOIN REQUESTS
+// .requests lists pending requests and approves/rejects them in bulk.
+// With .requests auto on, the scheduler checks the group every few minutes:
+// banned → reject, deny list → reject, not on allow list → reject,
+// captcha on → DM a sum to solve, otherwise approve. A digest is posted.
+
+const (
+	joinPoliciesKey    = "join_policies" // members: botID|chatID
+	joinCaptchaTimeout = 10 * time.Minute
+	joinCaptchaTries   = 3 // اتنے غلط جواب پر درخواست ریجیکٹ
+	joinPolicyEvery    = 2 * time.Minute
+)
+
+type JoinCaptcha struct {
+	Bot      string   `json:"bot"`
+	Chat     string   `json:"chat"`
+	User     string   `json:"user"` // درخواست والی JID
+	IDs      []string `json:"ids"`  // LID اور فون نمبر دونوں
+	Answer   string   `json:"answer"`
+	Expires  int64    `json:"expires"`
+	Attempts int      `json:"attempts"`            // غلط جوابات
+	TimedOut bool     `json:"timed_out,omitempty"` // ٹائم آؤٹ کا میسج جا چکا
+}
+
+// join_captcha:<bot>:<chat>:<user> ایک کیپچا
+// join_captcha_user:<bot>:<id> اس یوزر (LID یا نمبر) کے کیپچے، ہر ممبر "chat|user"
+func joinCaptchaKey(botID, chat, user string) string {
+	return "join_captcha:" + botID + ":" + chat + ":" + getCleanID(user)
+}
+
+func joinCaptchaUserKey(botID, user string) string {
+	return "join_captcha_user:" + botID + ":" + getCleanID(user)
+}
+
+// requestIDs درخواست دینے والے کی JID اور فون نمبر (LID ہو تو اسٹور سے)
+func requestIDs(client *whatsmeow.Client, jid types.JID) ([]string, string) {
+	ids := []string{jid.User}
+	phone := ""
+	if jid.Server == types.DefaultUserServer {
+		phone = jid.User
+	}
+	if alt := altJID(client, jid); !alt.IsEmpty() {
+		ids = append(ids, alt.User)
+		if alt.Server == types.DefaultUserServer {
+			phone = alt.User
+		}
+	}
+	return ids, phone
+}
+
+func matchesCountry(phone string, codes []string) bool {
+	for _, c := range codes {
+		if strings.HasPrefix(phone, strings.TrimPrefix(c, "+")) {
+			return true
+		}
+	}
+	return false
+}
+
+type joinDigest struct {
+	Approved []string
+	Rejected []string
+	Captcha  []string
+}
+
+func (d *joinDigest) empty() bool {
+	return len(d.Approved)+len(d.Rejected)+len(d.Captcha) == 0
+}
+
+// decideJoinRequest پالیسی کے مطابق فیصلہ: approve / reject / captcha
+// phone خالی ہو (صرف LID، نمبر معلوم نہیں) تو ملکوں والی لسٹیں لاگو نہیں ہوتیں
+func decideJoinRequest(s *GroupSettings, ids []string, phone string) (string, string) {
+	if isBanned(s, ids...) {
+		return "reject", "banned"
+	}
+	if phone != "" && matchesCountry(phone, s.JoinDeny) {
+		return "reject", "country"
+	}
+	if phone != "" && len(s.JoinAllow) > 0 && !matchesCountry(phone, s.JoinAllow) {
+		return "reject", "country"
+	}
+	if s.JoinCaptcha {
+		return "captcha", ""
+	}
+	return "approve", ""
+}
+
+// applyJoinPolicy ایک گروپ کی زیر التواء درخواستوں پر پالیسی لاگو کرتا ہے
+func applyJoinPolicy(client *whatsmeow.Client, chat types.JID, s *GroupSettings) (*joinDigest, error) {
+	reqs, err := client.GetGroupRequestParticipants(context.Background(), chat)
+	if err != nil {
+		return nil, err
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	d := &joinDigest{}
+	var approve, reject []types.JID
+
+	for _, r := range reqs {
+		ids, phone := requestIDs(client, r.JID)
+		action, reason := decideJoinRequest(s, ids, phone)
+		label := r.JID.User
+		if reason != "" {
+			label += " (" + reason + ")"
+		}
+
+		switch action {
+		case "approve":
+			approve = append(approve, r.JID)
+			d.Approved = append(d.Approved, label)
+		case "reject":
+			reject = append(reject, r.JID)
+			d.Rejected = append(d.Rejected, label)
+		case "captcha":
+			c := loadJoinCaptcha(botID, chat.String(), r.JID.User)
+			if c == nil {
+				if sendJoinCaptcha(client, chat, r.JID, ids) {
+					d.Captcha = append(d.Captcha, label)
+				}
+			} else if time.Now().Unix() > c.Expires {
+				reject = append(reject, r.JID)
+				d.Rejected = append(d.Rejected, r.JID.User+" (captcha timeout)")
+				clearJoinCaptcha(c)
+			}
+		}
+	}
+
+	if len(approve) > 0 {
+		client.UpdateGroupRequestParticipants(context.Background(), chat, approve, whatsmeow.ParticipantChangeApprove)
+	}
+	if len(reject) > 0 {
+		client.UpdateGroupRequestParticipants(context.Background(), chat, reject, whatsmeow.ParticipantChangeReject)
+	}
+	return d, nil
+}
+
+func postJoinDigest(client *whatsmeow.Client, chat types.JID, d *joinDigest) {
+	if d == nil || d.empty() {
+		return
+	}
+	list := func(items []string) string {
+		if len(items) == 0 {
+			return "-"
+		}
+		if len(items) > 15 {
+			return strings.Join(items[:15], "\n║ ") + fmt.Sprintf("\n║ ...and %d more", len(items)-15)
+		}
+		return strings.Join(items, "\n║ ")
+	}
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🛂 JOIN REQUESTS
+╠════════════════╣
+║ ✅ Approved: %d
+║ %s
+║ ❌ Rejected: %d
+║ %s
+║ 🧩 Captcha sent: %d
+║ %s
+╚════════════════╝`, len(d.Approved), list(d.Approved), len(d.Rejected), list(d.Rejected), len(d.Captcha), list(d.Captcha))
+	client.SendMessage(context.Background(), chat, &waProto.Message{
+		Conversation: proto.String(msg),
+	})
+}
+
+// runJoinPolicies شیڈولر سے (ہر چند منٹ)
+var lastJoinPolicyRun time.Time
+
+func runJoinPolicies() {
+	if rdb == nil || time.Since(lastJoinPolicyRun) < joinPolicyEvery {
+		return
+	}
+	lastJoinPolicyRun = time.Now()
+
+	members, err := rdb.SMembers(ctx, joinPoliciesKey).Result()
+	if err != nil {
+		return
+	}
+	for _, m := range members {
+		parts := strings.SplitN(m, "|", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		client := getClientForBot(parts[0])
+		if client == nil {
+			continue
+		}
+		s := getGroupSettings(parts[0], parts[1])
+		if !s.JoinPolicy {
+			rdb.SRem(ctx, joinPoliciesKey, m)
+			continue
+		}
+		chat, err := types.ParseJID(parts[1])
+		if err != nil {
+			continue
+		}
+		d, err := applyJoinPolicy(client, chat, s)
+		if err != nil {
+			fmt.Printf("⚠️ [JOINREQ] %s: %v\n", parts[1], err)
+			continue
+		}
+		postJoinDigest(client, chat, d)
+	}
+}
+
+// ---------------------------------------------------------
+// 🧩 DM CAPTCHA
+// ---------------------------------------------------------
+func loadJoinCaptcha(botID, chat, user string) *JoinCaptcha {
+	if rdb == nil {
+		return nil
+	}
+	data, err := rdb.Get(ctx, joinCaptchaKey(botID, chat, user)).Bytes()
+	if err != nil {
+		return nil
+	}
+	var c JoinCaptcha
+	if json.Unmarshal(data, &c) != nil {
+		return nil
+	}
+	return &c
+}
+
+// saveJoinCaptcha ٹائم آؤٹ کے بعد بھی کچھ دیر رکھیں تاکہ اگلا ٹِک ریجیکٹ کر سکے
+func saveJoinCaptcha(c *JoinCaptcha) {
+	ttl := time.Until(time.Unix(c.Expires, 0)) + time.Hour
+	if ttl <= 0 {
+		return
+	}
+	data, _ := json.Marshal(c)
+	rdb.Set(ctx, joinCaptchaKey(c.Bot, c.Chat, c.User), data, ttl)
+	for _, id := range c.IDs {
+		rdb.SAdd(ctx, joinCaptchaUserKey(c.Bot, id), c.ref())
+		rdb.Expire(ctx, joinCaptchaUserKey(c.Bot, id), ttl)
+	}
+}
+
+func (c *JoinCaptcha) ref() string {
+	return c.Chat + "|" + getCleanID(c.User)
+}
+
+func clearJoinCaptcha(c *JoinCaptcha) {
+	rdb.Del(ctx, joinCaptchaKey(c.Bot, c.Chat, c.User))
+	for _, id := range c.IDs {
+		rdb.SRem(ctx, joinCaptchaUserKey(c.Bot, id), c.ref())
+	}
+}
+
+// pendingJoinCaptchas اس DM بھیجنے والے کے تمام گروپس کے کیپچے
+func pendingJoinCaptchas(botID string, v *events.Message) []*JoinCaptcha {
+	var out []*JoinCaptcha
+	seen := make(map[string]bool)
+	for _, id := range senderIDs(v) {
+		refs, _ := rdb.SMembers(ctx, joinCaptchaUserKey(botID, id)).Result()
+		for _, ref := range refs {
+			parts := strings.SplitN(ref, "|", 2)
+			if len(parts) != 2 || seen[ref] {
+				continue
+			}
+			seen[ref] = true
+			if c := loadJoinCaptcha(botID, parts[0], parts[1]); c != nil {
+				out = append(out, c)
+			}
+		}
+	}
+	return out
+}
+
+func sendJoinCaptcha(client *whatsmeow.Client, chat, user types.JID, ids []string) bool {
+	if rdb == nil {
+		return false
+	}
+	botID := getCleanID(client.Store.ID.User)
+	// تین ہندسوں کے نمبر تاکہ اندازے سے جواب نہ ملے
+	a, b := rand.Intn(900)+100, rand.Intn(900)+100
+
+	groupName := "the group"
+	if info, err := client.GetGroupInfo(context.Background(), chat); err == nil {
+		groupName = info.Name
+	}
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🧩 JOIN CHECK
+╠════════════════╣
+║ You asked to join
+║ *%s*
+║
+║ Reply with the answer:
+║ *%d + %d = ?*
+║ ⏳ %d minutes
+╚════════════════╝`, groupName, a, b, int(joinCaptchaTimeout.Minutes()))
+	_, err := client.SendMessage(context.Background(), user, &waProto.Message{
+		Conversation: proto.String(msg),
+	})
+	if err != nil {
+		return false
+	}
+
+	saveJoinCaptcha(&JoinCaptcha{
+		Bot:     botID,
+		Chat:    chat.String(),
+		User:    user.String(),
+		IDs:     ids,
+		Answer:  strconv.Itoa(a + b),
+		Expires: time.Now().Add(joinCaptchaTimeout).Unix(),
+	})
+	return true
+}
+
+// handleJoinCaptchaReply DM میں کیپچا کا جواب؛ صرف نمبر والے میسج، باقی (کمانڈز وغیرہ) آگے جاتے ہیں
+func handleJoinCaptchaReply(client *whatsmeow.Client, v *events.Message, body string) bool {
+	if v.Info.IsGroup || v.Info.IsFromMe || rdb == nil {
+		return false
+	}
+	answer := strings.TrimSpace(body)
+	if _, err := strconv.Atoi(answer); err != nil {
+		return false
+	}
+	botID := getCleanID(client.Store.ID.User)
+
+	pending := pendingJoinCaptchas(botID, v)
+	if len(pending) == 0 {
+		return false
+	}
+
+	now := time.Now().Unix()
+	var open []*JoinCaptcha
+	for _, c := range pending {
+		if now <= c.Expires {
+			open = append(open, c)
+		}
+	}
+
+	if len(open) == 0 {
+		// ٹائم آؤٹ کا میسج صرف ایک بار
+		notify := false
+		for _, c := range pending {
+			if !c.TimedOut {
+				c.TimedOut = true
+				saveJoinCaptcha(c)
+				notify = true
+			}
+		}
+		if !notify {
+			return false
+		}
+		replyMessage(client, v, "⌛ Time is up. Your join request will be rejected; you can request again.")
+		return true
+	}
+
+	var c *JoinCaptcha
+	for _, o := range open {
+		if o.Answer == answer {
+			c = o
+			break
+		}
+	}
+	if c == nil {
+		rejected := 0
+		for _, o := range open {
+			o.Attempts++
+			if o.Attempts < joinCaptchaTries {
+				saveJoinCaptcha(o)
+				continue
+			}
+			clearJoinCaptcha(o)
+			user, err1 := types.ParseJID(o.User)
+			chat, err2 := types.ParseJID(o.Chat)
+			if err1 == nil && err2 == nil {
+				client.UpdateGroupRequestParticipants(context.Background(), chat, []types.JID{user}, whatsmeow.ParticipantChangeReject)
+			}
+			rejected++
+		}
+		if rejected > 0 {
+			replyMessage(client, v, "❌ Too many wrong answers. Your join request was rejected.")
+			return true
+		}
+		replyMessage(client, v, fmt.Sprintf("❌ Wrong answer, %d tries left.", joinCaptchaTries-open[0].Attempts))
+		return true
+	}
+
+	user, err1 := types.ParseJID(c.User)
+	chat, err2 := types.ParseJID(c.Chat)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+
+	clearJoinCaptcha(c)
+	_, err := client.UpdateGroupRequestParticipants(context.Background(), chat, []types.JID{user}, whatsmeow.ParticipantChangeApprove)
+	if err != nil {
+		replyMessage(client, v, "⚠️ Correct, but approval failed. An admin will review your request.")
+		return true
+	}
+	replyMessage(client, v, "✅ Correct! Your join request has been approved.")
+	return true
+}
+
+// ---------------------------------------------------------
+// 🛂 COMMAND: .requests
+// ---------------------------------------------------------
+func handleRequests(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	chatID := v.Info.Chat.String()
+	s := getGroupSettings(botID, chatID)
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch sub {
+	case "", "list":
+		reqs, err := client.GetGroupRequestParticipants(context.Background(), v.Info.Chat)
+		if err != nil {
+			replyMessage(client, v, "❌ "+explainGroupError(err))
+			return
+		}
+		if len(reqs) == 0 {
+			replyMessage(client, v, "✅ No pending join requests.")
+			return
+		}
+		sort.Slice(reqs, func(i, j int) bool { return reqs[i].RequestedAt.Before(reqs[j].RequestedAt) })
+		var lines []string
+		for i, r := range reqs {
+			if i == 30 {
+				lines = append(lines, fmt.Sprintf("...and %d more", len(reqs)-30))
+				break
+			}
+			lines = append(lines, fmt.Sprintf("%d. %s (%s)", i+1, r.JID.User, r.RequestedAt.Format("02 Jan")))
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🛂 PENDING (%d)
+╠════════════════╣
+║ %s
+╠════════════════╣
+║ .requests approve all|1 2
+║ .requests reject all|1 2
+║ .requests policy
+╚════════════════╝`, len(reqs), strings.Join(lines, "\n║ ")))
+
+	case "approve", "reject":
+		reqs, err := client.GetGroupRequestParticipants(context.Background(), v.Info.Chat)
+		if err != nil {
+			replyMessage(client, v, "❌ "+explainGroupError(err))
+			return
+		}
+		sort.Slice(reqs, func(i, j int) bool { return reqs[i].RequestedAt.Before(reqs[j].RequestedAt) })
+
+		var picked []types.JID
+		if len(args) < 2 || strings.ToLower(args[1]) == "all" {
+			for _, r := range reqs {
+				picked = append(picked, r.JID)
+			}
+		} else {
+			for _, a := range args[1:] {
+				n, err := strconv.Atoi(a)
+				if err == nil && n >= 1 && n <= len(reqs) {
+					picked = append(picked, reqs[n-1].JID)
+				}
+			}
+		}
+		if len(picked) == 0 {
+			replyMessage(client, v, "⚠️ Nothing to "+sub+".")
+			return
+		}
+
+		action := whatsmeow.ParticipantChangeApprove
+		if sub == "reject" {
+			action = whatsmeow.ParticipantChangeReject
+		}
+		_, err = client.UpdateGroupRequestParticipants(context.Background(), v.Info.Chat, picked, action)
+		if err != nil {
+			replyMessage(client, v, "❌ "+explainGroupError(err))
+			return
+		}
+		done := "Approved"
+		if sub == "reject" {
+			done = "Rejected"
+		}
+		replyMessage(client, v, fmt.Sprintf("✅ %s %d request(s).", done, len(picked)))
+
+	case "auto":
+		on := len(args) > 1 && strings.ToLower(args[1]) == "on"
+		s.JoinPolicy = on
+		saveGroupSettings(botID, s)
+		if rdb != nil {
+			if on {
+				rdb.SAdd(ctx, joinPoliciesKey, botID+"|"+chatID)
+			} else {
+				rdb.SRem(ctx, joinPoliciesKey, botID+"|"+chatID)
+			}
+		}
+		if !on {
+			replyMessage(client, v, "❌ *Auto Join Policy:* OFF")
+			return
+		}
+		// فوراً ایک بار چلائیں
+		d, err := applyJoinPolicy(client, v.Info.Chat, s)
+		if err != nil {
+			replyMessage(client, v, "⚠️ Policy ON, but requests could not be read: "+explainGroupError(err))
+			return
+		}
+		replyMessage(client, v, "✅ *Auto Join Policy:* ON")
+		postJoinDigest(client, v.Info.Chat, d)
+
+	case "allow", "deny":
+		var codes []string
+		for _, a := range args[1:] {
+			if strings.ToLower(a) == "clear" {
+				codes = nil
+				break
+			}
+			c := strings.TrimPrefix(a, "+")
+			if _, err := strconv.Atoi(c); err == nil {
+				codes = append(codes, "+"+c)
+			}
+		}
+		if sub == "allow" {
+			s.JoinAllow = codes
+		} else {
+			s.JoinDeny = codes
+		}
+		saveGroupSettings(botID, s)
+		if len(codes) == 0 {
+			replyMessage(client, v, "✅ "+sub+" list cleared")
+		} else {
+			replyMessage(client, v, "✅ "+sub+" list: "+strings.Join(codes, " "))
+		}
+
+	case "captcha":
+		s.JoinCaptcha = len(args) > 1 && strings.ToLower(args[1]) == "on"
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ *Join Captcha:* "+onOff(s.JoinCaptcha))
+
+	case "policy":
+		allow, deny := "Any", "None"
+		if len(s.JoinAllow) > 0 {
+			allow = strings.Join(s.JoinAllow, " ")
+		}
+		if len(s.JoinDeny) > 0 {
+			deny = strings.Join(s.JoinDeny, " ")
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🛂 JOIN POLICY
+╠════════════════╣
+║ 🤖 Auto: %s
+║ ✅ Allow: %s
+║ ❌ Deny: %s
+║ 🚫 Banned: %d
+║ 🧩 Captcha: %s
+╠════════════════╣
+║ .requests auto on/off
+║ .requests allow +92 +971
+║ .requests deny +91
+║ .requests allow clear
+║ .requests captcha on/off
+╚════════════════╝`, onOff(s.JoinPolicy), allow, deny, len(s.Banned), onOff(s.JoinCaptcha)))
+
+	default:
+		replyMessage(client, v, "⚠️ Usage: .requests [list|approve|reject|auto|allow|deny|captcha|policy]")
+	}
+}
@@ -0,0 +1,326 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 🔐 LOCK INFO
+// .lockinfo on saves the approved name, description and icon. Changes made by
+// anyone outside the trusted list are reverted; changes by trusted admins
+// become the new approved snapshot. Icon bytes are kept in their own Redis key
+// so GroupSettings stays small.
+
+func lockIconKey(botID, chatID string) string {
+	return "lockinfo_icon:" + botID + ":" + chatID
+}
+
+// isTrustedChanger بوٹ، اونر یا ٹرسٹڈ لسٹ والا یوزر
+func isTrustedChanger(client *whatsmeow.Client, s *GroupSettings, user types.JID) bool {
+	if user.IsEmpty() {
+		return false
+	}
+	if client.Store.ID != nil && user.User == client.Store.ID.User {
+		return true
+	}
+	if !client.Store.LID.IsEmpty() && user.User == client.Store.LID.User {
+		return true
+	}
+	if isOwner(client, user) {
+		return true
+	}
+	u := getCleanID(user.User)
+	for _, t := range s.TrustedAdmins {
+		if t == u {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshotGroupInfo موجودہ نام، تفصیل اور آئیکن کو منظور شدہ حالت کے طور پر محفوظ کرتا ہے
+func snapshotGroupInfo(client *whatsmeow.Client, chat types.JID, s *GroupSettings) error {
+	info, err := client.GetGroupInfo(context.Background(), chat)
+	if err != nil {
+		return err
+	}
+	s.LockName = info.Name
+	s.LockTopic = info.Topic
+
+	botID := getCleanID(client.Store.ID.User)
+	s.LockIcon = false
+	if rdb != nil {
+		if icon, err := downloadGroupIcon(client, chat); err == nil && len(icon) > 0 {
+			rdb.Set(ctx, lockIconKey(botID, chat.String()), icon, 0)
+			s.LockIcon = true
+		} else {
+			rdb.Del(ctx, lockIconKey(botID, chat.String()))
+		}
+	}
+	return nil
+}
+
+// handleLockInfoChange نام/تفصیل کی تبدیلی چیک کرتا ہے (handleGroupInfoChange سے)
+func handleLockInfoChange(client *whatsmeow.Client, v *events.GroupInfo, s *GroupSettings) {
+	if !s.LockInfo || (v.Name == nil && v.Topic == nil) {
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	var sender types.JID
+	if v.Sender != nil {
+		sender = *v.Sender
+	}
+
+	changed := false
+	if v.Name != nil && v.Name.Name != s.LockName {
+		by := v.Name.NameSetBy
+		if by.IsEmpty() {
+			by = sender
+		}
+		if isTrustedChanger(client, s, by) {
+			s.LockName = v.Name.Name
+			changed = true
+		} else {
+			err := client.SetGroupName(context.Background(), v.JID, s.LockName)
+			reportLockViolation(client, v.JID, by, "Name", err, s)
+		}
+	}
+
+	if v.Topic != nil && v.Topic.Topic != s.LockTopic {
+		by := v.Topic.TopicSetBy
+		if by.IsEmpty() {
+			by = sender
+		}
+		if isTrustedChanger(client, s, by) {
+			s.LockTopic = v.Topic.Topic
+			changed = true
+		} else {
+			err := client.SetGroupTopic(context.Background(), v.JID, v.Topic.TopicID, "", s.LockTopic)
+			reportLockViolation(client, v.JID, by, "Description", err, s)
+		}
+	}
+
+	if changed {
+		saveGroupSettings(botID, s)
+	}
+}
+
+// handleLockPicture گروپ آئیکن کی تبدیلی (events.Picture)
+func handleLockPicture(client *whatsmeow.Client, v *events.Picture) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("⚠️ [LOCKINFO] Panic: %v\n", r)
+		}
+	}()
+
+	if v.JID.Server != types.GroupServer {
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.JID.String())
+	if !s.LockInfo {
+		return
+	}
+
+	if isTrustedChanger(client, s, v.Author) {
+		// منظور شدہ تبدیلی، نیا آئیکن سنیپ شاٹ میں لے لیں
+		time.Sleep(2 * time.Second)
+		if err := snapshotGroupInfo(client, v.JID, s); err == nil {
+			saveGroupSettings(botID, s)
+		}
+		return
+	}
+
+	var icon []byte
+	if s.LockIcon && rdb != nil {
+		icon, _ = rdb.Get(ctx, lockIconKey(botID, v.JID.String())).Bytes()
+	}
+	// icon == nil ہو تو آئیکن ہٹا دیا جاتا ہے (پہلے بھی نہیں تھا)
+	_, err := client.SetGroupPhoto(context.Background(), v.JID, icon)
+	reportLockViolation(client, v.JID, v.Author, "Icon", err, s)
+}
+
+func reportLockViolation(client *whatsmeow.Client, chat, by types.JID, what string, revertErr error, s *GroupSettings) {
+	status := "♻️ Restored"
+	if revertErr != nil {
+		status = "❌ Restore failed"
+		fmt.Printf("❌ [LOCKINFO] %s revert failed in %s: %v\n", what, chat.String(), revertErr)
+	}
+
+	action := ""
+	if s.LockDemote && !by.IsEmpty() && isAdmin(client, chat, by) {
+		_, err := client.UpdateGroupParticipants(context.Background(), chat, []types.JID{by}, whatsmeow.ParticipantChangeDemote)
+		if err == nil {
+			action = "\n║ ⬇️ Offender demoted"
+			adminMutex.Lock()
+			delete(adminCacheMap, chat.String())
+			adminMutex.Unlock()
+		}
+	}
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🔐 INFO LOCKED
+╠════════════════╣
+║ 📝 %s changed
+║ 👤 By: @%s
+║ %s%s
+╚════════════════╝`, what, by.User, status, action)
+
+	mentions := []string{}
+	if !by.IsEmpty() {
+		mentions = append(mentions, by.String())
+	}
+	client.SendMessage(context.Background(), chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:        proto.String(msg),
+			ContextInfo: &waProto.ContextInfo{MentionedJID: mentions},
+		},
+	})
+}
+
+// ---------------------------------------------------------
+// ⚙️ COMMAND: .lockinfo on|off|snapshot|trust|untrust|demote
+// ---------------------------------------------------------
+func handleLockInfo(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	// جب لاک آن ہو تو ترتیب صرف ٹرسٹڈ ایڈمن بدل سکتے ہیں
+	if sub != "" && s.LockInfo && !isTrustedChanger(client, s, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only trusted admins can change Lock Info.")
+		return
+	}
+
+	switch sub {
+	case "on":
+		if err := snapshotGroupInfo(client, v.Info.Chat, s); err != nil {
+			replyMessage(client, v, "❌ Failed to read group info: "+err.Error())
+			return
+		}
+		s.LockInfo = true
+		addTrustedAdmin(s, v.Info.Sender)
+		saveGroupSettings(botID, s)
+		icon := "No"
+		if s.LockIcon {
+			icon = "Yes"
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🔐 LOCK INFO: ON
+╠════════════════╣
+║ 📛 %s
+║ 🖼️ Icon saved: %s
+║ 👮 Trusted: %d
+╚════════════════╝`, s.LockName, icon, len(s.TrustedAdmins)))
+
+	case "off":
+		s.LockInfo = false
+		saveGroupSettings(botID, s)
+		if rdb != nil {
+			rdb.Del(ctx, lockIconKey(botID, v.Info.Chat.String()))
+		}
+		replyMessage(client, v, "❌ *Lock Info:* OFF")
+
+	case "snapshot":
+		if err := snapshotGroupInfo(client, v.Info.Chat, s); err != nil {
+			replyMessage(client, v, "❌ Failed to read group info: "+err.Error())
+			return
+		}
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ Current name, description and icon saved as approved.")
+
+	case "trust", "untrust":
+		target, err := getTargetJID(v, args[1:])
+		if err != nil || target.User == "" {
+			replyMessage(client, v, "⚠️ Usage: .lockinfo "+sub+" @user (or reply)")
+			return
+		}
+		if sub == "trust" {
+			addTrustedAdmin(s, target)
+		} else {
+			u := getCleanID(target.User)
+			// نئی سلائس: دوسرے گوروٹینز پرانی لسٹ پڑھ رہے ہو سکتے ہیں
+			kept := make([]string, 0, len(s.TrustedAdmins))
+			for _, t := range s.TrustedAdmins {
+				if t != u {
+					kept = append(kept, t)
+				}
+			}
+			s.TrustedAdmins = kept
+		}
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ @%s %sed", target.User, sub))
+
+	case "demote":
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .lockinfo demote on|off")
+			return
+		}
+		s.LockDemote = strings.ToLower(args[1]) == "on"
+		saveGroupSettings(botID, s)
+		if s.LockDemote {
+			replyMessage(client, v, "✅ *Demote Offenders:* ON")
+		} else {
+			replyMessage(client, v, "❌ *Demote Offenders:* OFF")
+		}
+
+	default:
+		status, demote := "🔴 OFF", "OFF"
+		if s.LockInfo {
+			status = "🟢 ON"
+		}
+		if s.LockDemote {
+			demote = "ON"
+		}
+		trusted := "None"
+		if len(s.TrustedAdmins) > 0 {
+			trusted = strings.Join(s.TrustedAdmins, "\n║ ")
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🔐 LOCK INFO
+╠════════════════╣
+║ Status: %s
+║ Demote: %s
+║ 👮 Trusted:
+║ %s
+╠════════════════╣
+║ .lockinfo on/off
+║ .lockinfo snapshot
+║ .lockinfo trust @user
+║ .lockinfo untrust @user
+║ .lockinfo demote on/off
+╚════════════════╝`, status, demote, trusted))
+	}
+}
+
+func addTrustedAdmin(s *GroupSettings, user types.JID) {
+	u := getCleanID(user.User)
+	for _, t := range s.TrustedAdmins {
+		if t == u {
+			return
+		}
+	}
+	s.TrustedAdmins = append(s.TrustedAdmins, u)
+}
@@ -161,6 +161,7 @@ func main() {
 	initRedis()
 	loadPersistentUptime()
 	loadGlobalSettings()
+	loadAntiBugConfig()
 	startPersistentUptimeTracker()
 	SetupFeatures()
 	KeepServerAlive()
@@ -322,6 +323,7 @@ func main() {
 	fmt.Println("🤖 Initializing Multi-Bot System from Database...")
 	StartAllBots(container)
 	InitLIDSystem()
+	StartGroupScheduler()
 
 	// ----------------------------------------------------
 	// 🌐 ROUTES (Bot UI + Web View)
@@ -349,6 +351,9 @@ func main() {
 	// ✅ Status APIs (route now)
 	http.HandleFunc("/api/statuses", handleGetStatuses)
 
+	// 📒 Moderation log export (CSV, token protected)
+	http.HandleFunc("/api/modlog", handleModLogAPI)
+
 	// ----------------------------------------------------
 	// ✅ Health / Ready
 	// ----------------------------------------------------
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"google.golang.org/protobuf/proto"
+)
+
+// 📦 STORED MEDIA
+// Media saved by admins (welcome images, notes, auto replies) is re-uploaded
+// once by the bot and only the WhatsApp upload reference is kept in Redis.
+// Sending it again never needs the original bytes.
+
+type StoredMedia struct {
+	Kind          string `json:"kind"` // image | video | sticker | audio | document
+	URL           string `json:"url"`
+	DirectPath    string `json:"direct_path"`
+	MediaKey      []byte `json:"media_key"`
+	FileEncSHA256 []byte `json:"file_enc_sha256"`
+	FileSHA256    []byte `json:"file_sha256"`
+	FileLength    uint64 `json:"file_length"`
+	Mimetype      string `json:"mimetype"`
+	FileName      string `json:"file_name,omitempty"`
+	PTT           bool   `json:"ptt,omitempty"`
+}
+
+// uploadStoredMedia ڈیٹا اپلوڈ کر کے ریفرنس واپس کرتا ہے
+func uploadStoredMedia(client *whatsmeow.Client, data []byte, kind, mime, fileName string) (*StoredMedia, error) {
+	var mType whatsmeow.MediaType
+	switch kind {
+	case "image", "sticker":
+		mType = whatsmeow.MediaImage
+	case "video":
+		mType = whatsmeow.MediaVideo
+	case "audio":
+		mType = whatsmeow.MediaAudio
+	default:
+		kind = "document"
+		mType = whatsmeow.MediaDocument
+	}
+
+	up, err := client.Upload(context.Background(), data, mType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &StoredMedia{
+		Kind:          kind,
+		URL:           up.URL,
+		DirectPath:    up.DirectPath,
+		MediaKey:      up.MediaKey,
+		FileEncSHA256: up.FileEncSHA256,
+		FileSHA256:    up.FileSHA256,
+		FileLength:    uint64(len(data)),
+		Mimetype:      mime,
+		FileName:      fileName,
+	}, nil
+}
+
+// captureMedia کسی میسج (عام طور پر quoted) سے میڈیا لے کر محفوظ کرتا ہے
+func captureMedia(client *whatsmeow.Client, m *waProto.Message) (*StoredMedia, error) {
+	if m == nil {
+		return nil, fmt.Errorf("no media")
+	}
+
+	var (
+		d        whatsmeow.DownloadableMessage
+		kind     string
+		mime     string
+		fileName string
+		ptt      bool
+	)
+
+	switch {
+	case m.ImageMessage != nil:
+		d, kind, mime = m.ImageMessage, "image", m.ImageMessage.GetMimetype()
+	case m.VideoMessage != nil:
+		d, kind, mime = m.VideoMessage, "video", m.VideoMessage.GetMimetype()
+	case m.StickerMessage != nil:
+		d, kind, mime = m.StickerMessage, "sticker", "image/webp"
+	case m.AudioMessage != nil:
+		d, kind, mime = m.AudioMessage, "audio", m.AudioMessage.GetMimetype()
+		ptt = m.AudioMessage.GetPTT()
+	case m.DocumentMessage != nil:
+		d, kind, mime = m.DocumentMessage, "document", m.DocumentMessage.GetMimetype()
+		fileName = m.DocumentMessage.GetFileName()
+	default:
+		return nil, fmt.Errorf("no media")
+	}
+
+	data, err := client.Download(context.Background(), d)
+	if err != nil {
+		return nil, err
+	}
+
+	sm, err := uploadStoredMedia(client, data, kind, mime, fileName)
+	if err != nil {
+		return nil, err
+	}
+	sm.PTT = ptt
+	return sm, nil
+}
+
+// fetchGroupIcon گروپ کی موجودہ تصویر ڈاؤنلوڈ کر کے اپلوڈ کرتا ہے
+func fetchGroupIcon(client *whatsmeow.Client, chat types.JID) (*StoredMedia, error) {
+	data, err := downloadGroupIcon(client, chat)
+	if err != nil {
+		return nil, err
+	}
+	return uploadStoredMedia(client, data, "image", "image/jpeg", "")
+}
+
+const maxGroupIconSize = 5 << 20
+
+// downloadGroupIcon گروپ آئیکن کی اصل بائٹس (JPEG)
+func downloadGroupIcon(client *whatsmeow.Client, chat types.JID) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	pic, err := client.GetProfilePictureInfo(ctx, chat, &whatsmeow.GetProfilePictureParams{})
+	if err != nil {
+		return nil, err
+	}
+	if pic == nil || pic.URL == "" {
+		return nil, fmt.Errorf("no group icon")
+	}
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", pic.URL, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("icon download failed: HTTP %d", resp.StatusCode)
+	}
+	// ایک حد سے بڑی فائل تصویر نہیں ہو سکتی
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxGroupIconSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxGroupIconSize {
+		return nil, fmt.Errorf("group icon too large")
+	}
+	return data, nil
+}
+
+// toMessage محفوظ میڈیا کو بھیجنے کے قابل میسج میں بدلتا ہے
+func (m *StoredMedia) toMessage(caption string, ci *waProto.ContextInfo) *waProto.Message {
+	switch m.Kind {
+	case "image":
+		return &waProto.Message{ImageMessage: &waProto.ImageMessage{
+			URL:           proto.String(m.URL),
+			DirectPath:    proto.String(m.DirectPath),
+			MediaKey:      m.MediaKey,
+			FileEncSHA256: m.FileEncSHA256,
+			FileSHA256:    m.FileSHA256,
+			FileLength:    proto.Uint64(m.FileLength),
+			Mimetype:      proto.String(m.Mimetype),
+			Caption:       proto.String(caption),
+			ContextInfo:   ci,
+		}}
+	case "video":
+		return &waProto.Message{VideoMessage: &waProto.VideoMessage{
+			URL:           proto.String(m.URL),
+			DirectPath:    proto.String(m.DirectPath),
+			MediaKey:      m.MediaKey,
+			FileEncSHA256: m.FileEncSHA256,
+			FileSHA256:    m.FileSHA256,
+			FileLength:    proto.Uint64(m.FileLength),
+			Mimetype:      proto.String(m.Mimetype),
+			Caption:       proto.String(caption),
+			ContextInfo:   ci,
+		}}
+	case "sticker":
+		return &waProto.Message{StickerMessage: &waProto.StickerMessage{
+			URL:           proto.String(m.URL),
+			DirectPath:    proto.String(m.DirectPath),
+			MediaKey:      m.MediaKey,
+			FileEncSHA256: m.FileEncSHA256,
+			FileSHA256:    m.FileSHA256,
+			FileLength:    proto.Uint64(m.FileLength),
+			Mimetype:      proto.String(m.Mimetype),
+			ContextInfo:   ci,
+		}}
+	case "audio":
+		return &waProto.Message{AudioMessage: &waProto.AudioMessage{
+			URL:           proto.String(m.URL),
+			DirectPath:    proto.String(m.DirectPath),
+			MediaKey:      m.MediaKey,
+			FileEncSHA256: m.FileEncSHA256,
+			FileSHA256:    m.FileSHA256,
+			FileLength:    proto.Uint64(m.FileLength),
+			Mimetype:      proto.String(m.Mimetype),
+			PTT:           proto.Bool(m.PTT),
+			ContextInfo:   ci,
+		}}
+	default:
+		return &waProto.Message{DocumentMessage: &waProto.DocumentMessage{
+			URL:           proto.String(m.URL),
+			DirectPath:    proto.String(m.DirectPath),
+			MediaKey:      m.MediaKey,
+			FileEncSHA256: m.FileEncSHA256,
+			FileSHA256:    m.FileSHA256,
+			FileLength:    proto.Uint64(m.FileLength),
+			Mimetype:      proto.String(m.Mimetype),
+			FileName:      proto.String(m.FileName),
+			Caption:       proto.String(caption),
+			ContextInfo:   ci,
+		}}
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 📜 MENU DATA
+// The menu is rendered from this table so per-group disabled commands can be
+// hidden. Cat is the category used by .disable; it defaults to the section key.
+
+type menuItem struct {
+	Cmd  string
+	Desc string
+	Cat  string
+}
+
+type menuSection struct {
+	Key    string
+	Header string
+	Items  []menuItem
+}
+
+var menuSections = []menuSection{
+	{"movie", "╭── 🎬 MOVIE & STREAMS ──╮", []menuItem{
+		{"movie", "Movie Download", ""},
+		{"yt", "YouTube Video", ""},
+		{"yts", "YT Search", ""},
+		{"dm", "DailyMotion", ""},
+		{"vimeo", "Vimeo Pro", ""},
+		{"rumble", "Rumble", ""},
+		{"bilibili", "Anime", ""},
+		{"douyin", "Chinese TT", ""},
+		{"kwai", "Kwai Video", ""},
+		{"bitchute", "BitChute", ""},
+		{"ted", "TED Talks", ""},
+		{"twitch", "Twitch Clips", ""},
+	}},
+	{"music", "╭─── 🎵 MUSIC STUDIO ────╮", []menuItem{
+		{"spotify", "Spotify", ""},
+		{"sc", "SoundCloud", ""},
+		{"apple", "Apple Music", ""},
+		{"deezer", "Deezer", ""},
+		{"tidal", "Tidal HQ", ""},
+		{"mixcloud", "DJ Sets", ""},
+		{"napster", "Napster", ""},
+		{"bandcamp", "Indie", ""},
+	}},
+	{"social", "╭── 📱 SOCIAL MEDIA ─────╮", []menuItem{
+		{"fb", "Facebook", ""},
+		{"ig", "Instagram", ""},
+		{"tt", "TikTok (No-WM)", ""},
+		{"tw", "Twitter/X", ""},
+		{"pin", "Pinterest", ""},
+		{"snap", "Snapchat", ""},
+		{"threads", "Threads", ""},
+		{"reddit", "Reddit", ""},
+		{"9gag", "9GAG Fun", ""},
+		{"ifunny", "iFunny Memes", ""},
+	}},
+	{"web", "╭── 🌐 WEB & SEARCH ────╮", []menuItem{
+		{"mega", "Mega/File DL", ""},
+		{"git", "GitHub Repo", ""},
+		{"imgur", "Imgur Media", ""},
+		{"archive", "Web Archive", ""},
+		{"steam", "Steam Games", ""},
+		{"giphy", "GIF Search", ""},
+		{"flickr", "Flickr Image", ""},
+		{"google", "Google Search", ""},
+		{"weather", "Weather Info", ""},
+	}},
+	{"utils", "╭─── 🧠 AI & UTILS ─────╮", []menuItem{
+		{"ai", "Gemini AI", "ai"},
+		{"gpt", "Chat GPT-4o", "ai"},
+		{"img", "Image Gen", "ai"},
+		{"afk", "Away Status", ""},
+		{"remini", "HD Upscale", ""},
+		{"removebg", "BG Remove", ""},
+		{"tr", "Translate", ""},
+		{"fancy", "Fancy Text", ""},
+		{"ss", "Screenshot", ""},
+		{"stats", "System Stats", ""},
+		{"speed", "Internet Speed", ""},
+		{"ping", "Bot Response", ""},
+		{"id", "Chat/User ID", ""},
+		{"data", "Data Status", ""},
+		{"owner", "Owner Card", ""},
+		{"poll", "Create Poll", ""},
+		{"pollresult", "Poll Results", ""},
+		{"pollclose", "Close Poll", ""},
+	}},
+	{"media", "╭─── 🎨 MEDIA TOOLS ────╮", []menuItem{
+		{"sticker", "To Sticker", ""},
+		{"toimg", "Sticker2Img", ""},
+		{"togif", "Sticker2Gif", ""},
+		{"tovideo", "Sticker2Vid", ""},
+		{"tourl", "Media URL", ""},
+		{"toptt", "Text to Audio", ""},
+		{"vv", "Anti-ViewOnce", ""},
+	}},
+	{"admin", "╭── 👥 GROUP ADMIN ─────╮", []menuItem{
+		{"add", "Add User", ""},
+		{"kick", "Kick User", ""},
+		{"warn", "Warn User", ""},
+		{"promote", "Make Admin", ""},
+		{"demote", "Demote", ""},
+		{"group", "Settings", ""},
+		{"tagall", "Tag All [admins|members]", ""},
+		{"hidetag", "Hidden Tag", ""},
+		{"welcome", "Welcome", ""},
+		{"setwelcome", "Welcome Text", ""},
+		{"setgoodbye", "Goodbye Text", ""},
+		{"testwelcome", "Preview", ""},
+		{"rules", "Group Rules", ""},
+		{"setrules", "Set Rules", ""},
+		{"del", "Delete Msg", ""},
+		{"report", "Report Msg", ""},
+		{"reportto", "Report Inbox", ""},
+		{"topactive", "Leaderboard", ""},
+		{"mystats", "My Activity", ""},
+		{"inactive", "Inactive List", ""},
+	}},
+	{"security", "╭── 🛡️ GROUP SECURITY ──╮", []menuItem{
+		{"mode", "Public/Admin", ""},
+		{"antilink", "Block Links", ""},
+		{"antipic", "Block Pics", ""},
+		{"antivideo", "Block Vids", ""},
+		{"antisticker", "Block Sticker", ""},
+		{"lockinfo", "Lock Name/Icon", ""},
+		{"slowmode", "Slow Mode", ""},
+		{"requests", "Join Requests", ""},
+		{"ban", "Ban User", ""},
+		{"unban", "Unban User", ""},
+		{"role", "Moderator Roles", ""},
+		{"mute", "Mute User", ""},
+		{"unmute", "Unmute User", ""},
+		{"disable", "Disable Cmd/Category", ""},
+		{"enable", "Enable Cmd/Category", ""},
+		{"disabled", "Disabled List", ""},
+		{"modlog", "Moderation Log", ""},
+		{"undo", "Undo Last Action", ""},
+		{"reactmod", "Reaction Moderation", ""},
+	}},
+	{"content", "╭── 📝 NOTES & REPLIES ──╮", []menuItem{
+		{"save", "Save Note", ""},
+		{"get", "Get Note (#name)", ""},
+		{"notes", "List Notes", ""},
+		{"clear", "Delete Note", ""},
+		{"autoresponder", "Keyword Replies", ""},
+		{"addcmd", "Add Custom Cmd", ""},
+		{"delcmd", "Delete Custom Cmd", ""},
+	}},
+	{"owner", "╭── ⚙️ OWNER CONTROL ───╮", []menuItem{
+		{"setprefix", "Set Prefix", ""},
+		{"alwaysonline", "24/7 On", ""},
+		{"autoread", "Auto Seen", ""},
+		{"autoreact", "Auto Like", ""},
+		{"autostatus", "View Status", ""},
+		{"statusreact", "Like Status", ""},
+		{"addstatus", "Add Target", ""},
+		{"delstatus", "Del Target", ""},
+		{"liststatus", "List Target", ""},
+		{"readallstatus", "Read All", ""},
+		{"antidelete", "set/on/off", ""},
+		{"listbots", "Active Bots", ""},
+		{"network", "Linked Groups", ""},
+		{"confirm", "Confirm Settings", ""},
+	}},
+}
+
+// category آئٹم کی کیٹیگری (ڈیفالٹ: سیکشن)
+func (it menuItem) category(sec menuSection) string {
+	if it.Cat != "" {
+		return it.Cat
+	}
+	return sec.Key
+}
+
+// renderMenuSections غیر فعال کمانڈز چھوڑ کر مینو کے سیکشنز بناتا ہے
+func renderMenuSections(p string, s *GroupSettings, extra ...menuSection) string {
+	sections := append(append([]menuSection(nil), menuSections...), extra...)
+
+	var sb strings.Builder
+	for _, sec := range sections {
+		var lines []string
+		for _, it := range sec.Items {
+			if s != nil && (isCommandDisabled(s, it.Cmd) || isCategoryDisabled(s, it.category(sec))) {
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("║ │ 🔸 *%s%s* - %s", p, it.Cmd, it.Desc))
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		sb.WriteString("║\n║ " + sec.Header + "\n")
+		sb.WriteString(strings.Join(lines, "\n"))
+		sb.WriteString("\n║ ╰───────────────────────╯\n")
+	}
+	return sb.String()
+}
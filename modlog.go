@@ -0,0 +1,218 @@
+package main
+
+import (
+	"crypto/subtle"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// 📒 MODERATION AUDIT LOG
+// Every moderation or settings change is pushed to a capped Redis list:
+//   modlog:<bot>:<chat>   (bot-wide settings use chat "global")
+// Admins read it with .modlog; the CSV export at /api/modlog needs the
+// MODLOG_API_TOKEN env var (disabled when unset).
+
+const (
+	modLogMax    = 1000
+	modLogGlobal = "global"
+)
+
+type ModLogEntry struct {
+	Time   int64  `json:"time"`
+	Bot    string `json:"bot"`
+	Group  string `json:"group"`
+	Actor  string `json:"actor"`
+	Target string `json:"target,omitempty"`
+	Action string `json:"action"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func modLogKey(botID, chat string) string {
+	if chat == "" {
+		chat = modLogGlobal
+	}
+	return "modlog:" + botID + ":" + chat
+}
+
+// logModAction آڈٹ لاگ میں اندراج (chat خالی = بوٹ لیول سیٹنگ)
+func logModAction(client *whatsmeow.Client, chat, actor, target types.JID, action, reason string) {
+	botID := getCleanID(client.Store.ID.User)
+	e := newModLogEntry(botID, chat, actor, target, action, reason)
+
+	fmt.Printf("📒 [MODLOG] Bot:%s | %s | %s -> %s | %s\n", botID, e.Action, e.Actor, e.Target, e.Reason)
+	if rdb == nil {
+		return
+	}
+	data, _ := json.Marshal(e)
+	key := modLogKey(botID, e.Group)
+	rdb.LPush(ctx, key, data)
+	rdb.LTrim(ctx, key, 0, modLogMax-1)
+}
+
+// newModLogEntry لاگ کی ایک انٹری (خالی actor = بوٹ)
+func newModLogEntry(botID string, chat, actor, target types.JID, action, reason string) ModLogEntry {
+	e := ModLogEntry{
+		Time:   time.Now().Unix(),
+		Bot:    botID,
+		Action: action,
+		Reason: strings.ReplaceAll(reason, "\n║ ", " "),
+	}
+	if !chat.IsEmpty() {
+		e.Group = chat.String()
+	}
+	if !actor.IsEmpty() {
+		e.Actor = actor.ToNonAD().String()
+	} else {
+		e.Actor = "bot"
+	}
+	if !target.IsEmpty() {
+		e.Target = target.ToNonAD().String()
+	}
+	return e
+}
+
+// loadModLog تازہ ترین پہلے
+func loadModLog(botID, chat string, limit int64) []ModLogEntry {
+	if rdb == nil {
+		return nil
+	}
+	raw, err := rdb.LRange(ctx, modLogKey(botID, chat), 0, limit-1).Result()
+	if err != nil {
+		return nil
+	}
+	out := make([]ModLogEntry, 0, len(raw))
+	for _, r := range raw {
+		var e ModLogEntry
+		if json.Unmarshal([]byte(r), &e) == nil {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func shortJID(s string) string {
+	if s == "" || s == "bot" {
+		return s
+	}
+	return getCleanID(s)
+}
+
+// ---------------------------------------------------------
+// 📒 COMMAND: .modlog [@user] [n]
+// ---------------------------------------------------------
+func handleModLog(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+
+	limit := 10
+	var filter string
+	for _, a := range args {
+		if n, err := strconv.Atoi(a); err == nil && n > 0 && len(a) <= 3 {
+			limit = n
+			continue
+		}
+		if strings.TrimLeft(strings.TrimPrefix(a, "@"), "+0123456789") == "" {
+			if t, err := getTargetJID(v, []string{a}); err == nil {
+				filter = getCleanID(t.User)
+			}
+		}
+	}
+	if filter == "" && len(args) == 0 {
+		if t, _ := getTargetJID(v, nil); t.User != "" {
+			filter = getCleanID(t.User)
+		}
+	}
+	if limit > 50 {
+		limit = 50
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	entries := loadModLog(botID, v.Info.Chat.String(), modLogMax)
+
+	var sb strings.Builder
+	shown := 0
+	for _, e := range entries {
+		if shown >= limit {
+			break
+		}
+		if filter != "" && shortJID(e.Actor) != filter && shortJID(e.Target) != filter {
+			continue
+		}
+		line := fmt.Sprintf("║ 🕒 %s\n║ %s by %s", time.Unix(e.Time, 0).Format("02 Jan 15:04"), e.Action, shortJID(e.Actor))
+		if e.Target != "" {
+			line += " → " + shortJID(e.Target)
+		}
+		if e.Reason != "" {
+			line += "\n║ 📝 " + e.Reason
+		}
+		sb.WriteString(line + "\n╟────────────────\n")
+		shown++
+	}
+	if shown == 0 {
+		sb.WriteString("║ No entries\n")
+	}
+
+	title := "📒 MOD LOG"
+	if filter != "" {
+		title += " (" + filter + ")"
+	}
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ %s
+╠════════════════╣
+%s╚════════════════╝`, title, sb.String()))
+}
+
+// ---------------------------------------------------------
+// 🌐 API: /api/modlog?bot_id=..&group=..  (Authorization: Bearer <token>)
+// ---------------------------------------------------------
+func handleModLogAPI(w http.ResponseWriter, r *http.Request) {
+	token := os.Getenv("MODLOG_API_TOKEN")
+	if token == "" {
+		http.Error(w, "modlog export disabled", http.StatusForbidden)
+		return
+	}
+
+	// صرف ہیڈر میں؛ URL والا ٹوکن لاگز اور ہسٹری میں رہ جاتا ہے
+	given := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	rawBotID := r.URL.Query().Get("bot_id")
+	if rawBotID == "" {
+		http.Error(w, "bot_id required", http.StatusBadRequest)
+		return
+	}
+	botID := getCleanID(rawBotID)
+	group := r.URL.Query().Get("group")
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="modlog_%s.csv"`, botID))
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"time", "bot", "group", "actor", "target", "action", "reason"})
+	for _, e := range loadModLog(botID, group, modLogMax) {
+		cw.Write([]string{
+			time.Unix(e.Time, 0).UTC().Format(time.RFC3339),
+			e.Bot, e.Group, e.Actor, e.Target, e.Action, e.Reason,
+		})
+	}
+	cw.Flush()
+}
@@ -0,0 +1,415 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 🕸️ GROUP NETWORKS
+// Groups run by the same bot can be linked into a network. With sharing on,
+// bans and mutes made in one group apply to every linked group, and the
+// network's word filter removes matching messages everywhere.
+// Network: network:<bot>:<name> (JSON)   Index: networks:<bot> (set)
+// GroupSettings.Network holds "<bot>:<name>".
+// A cached network is read by every message goroutine, so it is never changed
+// in place: writers clone it, change the copy and save that.
+
+type GroupNetwork struct {
+	ID         string           `json:"id"` // <bot>:<name>
+	Name       string           `json:"name"`
+	Groups     []string         `json:"groups"`
+	Banned     []string         `json:"banned"`
+	Muted      map[string]int64 `json:"muted"`
+	Filters    []string         `json:"filters"`
+	ShareBans  bool             `json:"share_bans"`
+	ShareMutes bool             `json:"share_mutes"`
+}
+
+var (
+	networkCache = make(map[string]*GroupNetwork)
+	networkMutex sync.RWMutex
+)
+
+func networksIndexKey(botID string) string { return "networks:" + botID }
+
+// loadNetwork ID سے نیٹ ورک (کیش پہلے)
+func loadNetwork(id string) *GroupNetwork {
+	if id == "" {
+		return nil
+	}
+	networkMutex.RLock()
+	n, ok := networkCache[id]
+	networkMutex.RUnlock()
+	if ok {
+		return n
+	}
+	if rdb == nil {
+		return nil
+	}
+	data, err := rdb.Get(ctx, "network:"+id).Bytes()
+	if err != nil {
+		return nil
+	}
+	var loaded GroupNetwork
+	if json.Unmarshal(data, &loaded) != nil {
+		return nil
+	}
+	networkMutex.Lock()
+	networkCache[id] = &loaded
+	networkMutex.Unlock()
+	return &loaded
+}
+
+// clone بدلنے کے لیے گہری کاپی
+func (n *GroupNetwork) clone() *GroupNetwork {
+	c := *n
+	c.Groups = append([]string(nil), n.Groups...)
+	c.Banned = append([]string(nil), n.Banned...)
+	c.Filters = append([]string(nil), n.Filters...)
+	c.Muted = cloneMap(n.Muted)
+	return &c
+}
+
+func saveNetwork(n *GroupNetwork) {
+	networkMutex.Lock()
+	networkCache[n.ID] = n
+	networkMutex.Unlock()
+	if rdb == nil {
+		return
+	}
+	data, _ := json.Marshal(n)
+	rdb.Set(ctx, "network:"+n.ID, data, 0)
+	rdb.SAdd(ctx, networksIndexKey(strings.SplitN(n.ID, ":", 2)[0]), n.Name)
+}
+
+func groupNetwork(s *GroupSettings) *GroupNetwork {
+	return loadNetwork(s.Network)
+}
+
+// ---------------------------------------------------------
+// 🔗 Shared ban / mute helpers (ban.go اور roles.go سے)
+// ---------------------------------------------------------
+func networkBanned(s *GroupSettings, ids ...string) bool {
+	n := groupNetwork(s)
+	if n == nil || !n.ShareBans {
+		return false
+	}
+	for _, b := range n.Banned {
+		for _, id := range ids {
+			if id != "" && b == getCleanID(id) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func networkMuted(s *GroupSettings, ids ...string) bool {
+	n := groupNetwork(s)
+	if n == nil || !n.ShareMutes || len(n.Muted) == 0 {
+		return false
+	}
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		if until, ok := n.Muted[getCleanID(id)]; ok && muteActive(until) {
+			return true
+		}
+	}
+	return false
+}
+
+// setNetworkBan نیٹ ورک لسٹ اپڈیٹ؛ بین پر باقی گروپس سے بھی نکالتا ہے
+func setNetworkBan(client *whatsmeow.Client, s *GroupSettings, target types.JID, ban bool) int {
+	n := groupNetwork(s)
+	if n == nil || !n.ShareBans {
+		return 0
+	}
+	id := getCleanID(target.User)
+	n = n.clone()
+	n.Banned, _ = toggleList(n.Banned, id, ban)
+	saveNetwork(n)
+	if !ban {
+		return 0
+	}
+
+	removed := 0
+	for _, g := range n.Groups {
+		if g == s.ChatID {
+			continue
+		}
+		chat, err := types.ParseJID(g)
+		if err != nil {
+			continue
+		}
+		res, err := client.UpdateGroupParticipants(context.Background(), chat, []types.JID{target}, whatsmeow.ParticipantChangeRemove)
+		if err == nil && (len(res) == 0 || res[0].Error == 0) {
+			removed++
+			logModAction(client, chat, types.EmptyJID, target, "kick", "network ban ("+n.Name+")")
+		}
+	}
+	return removed
+}
+
+func setNetworkMute(s *GroupSettings, target types.JID, until int64, mute bool) {
+	n := groupNetwork(s)
+	if n == nil || !n.ShareMutes {
+		return
+	}
+	id := getCleanID(target.User)
+	n = n.clone()
+	if mute {
+		n.Muted[id] = until
+	} else {
+		delete(n.Muted, id)
+	}
+	saveNetwork(n)
+}
+
+// ---------------------------------------------------------
+// 🧹 NETWORK WORD FILTER
+// ---------------------------------------------------------
+func matchNetworkFilter(n *GroupNetwork, text string) string {
+	lower := strings.ToLower(text)
+	for _, f := range n.Filters {
+		if strings.Contains(lower, f) {
+			return f
+		}
+	}
+	return ""
+}
+
+// handleNetworkFilter true واپس کرتا ہے اگر میسج ڈیلیٹ کر دیا گیا
+func handleNetworkFilter(client *whatsmeow.Client, v *events.Message) bool {
+	// revoke/edit جیسے پروٹوکول میسج ممبر کا نیا میسج نہیں
+	if !v.Info.IsGroup || v.Info.IsFromMe || v.Message.GetProtocolMessage() != nil {
+		return false
+	}
+	botID := getCleanID(client.Store.ID.User)
+	n := groupNetwork(getGroupSettings(botID, v.Info.Chat.String()))
+	if n == nil || len(n.Filters) == 0 {
+		return false
+	}
+	word := matchNetworkFilter(n, getText(v.Message))
+	if word == "" || isAdmin(client, v.Info.Chat, v.Info.Sender) || isOwner(client, v.Info.Sender) {
+		return false
+	}
+
+	_, err := client.SendMessage(context.Background(), v.Info.Chat, client.BuildRevoke(v.Info.Chat, v.Info.Sender, v.Info.ID))
+	if err != nil {
+		return false
+	}
+	logModAction(client, v.Info.Chat, types.EmptyJID, v.Info.Sender, "auto delete", "network filter: "+word)
+	if shouldNotifyMember(v.Info.Chat.String(), v.Info.Sender.User, "filter", time.Minute) {
+		client.SendMessage(context.Background(), v.Info.Sender.ToNonAD(), &waProto.Message{
+			Conversation: proto.String("🧹 Your message was removed: it contains a blocked word."),
+		})
+	}
+	return true
+}
+
+// ---------------------------------------------------------
+// 🕸️ COMMAND: .network create|add|remove|share|filter|list|info
+// ---------------------------------------------------------
+func handleNetwork(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Owner Only!")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	usage := `╔════════════════╗
+║ 🕸️ NETWORK
+╠════════════════╣
+║ .network create <name>
+║ .network add <name>
+║ .network remove
+║ .network share bans on|off
+║ .network share mutes on|off
+║ .network filter add <word>
+║ .network filter del <word>
+║ .network list
+║ .network info
+╚════════════════╝`
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+		args = args[1:]
+	}
+
+	if sub == "list" {
+		var names []string
+		if rdb != nil {
+			names, _ = rdb.SMembers(ctx, networksIndexKey(botID)).Result()
+		}
+		if len(names) == 0 {
+			replyMessage(client, v, "🕸️ No networks yet.\n.network create <name>")
+			return
+		}
+		var sb strings.Builder
+		for _, name := range names {
+			if n := loadNetwork(botID + ":" + name); n != nil {
+				sb.WriteString(fmt.Sprintf("║ 🔗 %s (%d groups)\n", n.Name, len(n.Groups)))
+			}
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🕸️ NETWORKS
+╠════════════════╣
+%s╚════════════════╝`, sb.String()))
+		return
+	}
+
+	if !v.Info.IsGroup {
+		replyMessage(client, v, usage)
+		return
+	}
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	chatID := v.Info.Chat.String()
+
+	switch sub {
+	case "create", "add":
+		if len(args) == 0 {
+			replyMessage(client, v, usage)
+			return
+		}
+		name := strings.ToLower(args[0])
+		id := botID + ":" + name
+		n := loadNetwork(id)
+		if sub == "create" && n != nil {
+			replyMessage(client, v, "⚠️ Network *"+name+"* already exists. Use .network add "+name)
+			return
+		}
+		if sub == "add" && n == nil {
+			replyMessage(client, v, "❌ No network named *"+name+"*. Use .network create "+name)
+			return
+		}
+		if n == nil {
+			n = &GroupNetwork{ID: id, Name: name, ShareBans: true}
+		} else {
+			n = n.clone()
+		}
+		if s.Network != "" && s.Network != id {
+			unlinkGroup(s)
+		}
+		n.Groups, _ = toggleList(n.Groups, chatID, true)
+		saveNetwork(n)
+		s.Network = id
+		saveGroupSettings(botID, s)
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "network add", name)
+		replyMessage(client, v, fmt.Sprintf("✅ Group linked to network *%s* (%d groups)", name, len(n.Groups)))
+
+	case "remove", "leave":
+		if s.Network == "" {
+			replyMessage(client, v, "⚠️ This group is not in a network.")
+			return
+		}
+		name := strings.SplitN(s.Network, ":", 2)[1]
+		unlinkGroup(s)
+		saveGroupSettings(botID, s)
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "network remove", name)
+		replyMessage(client, v, "✅ Group removed from network *"+name+"*")
+
+	case "share":
+		n := groupNetwork(s)
+		if n == nil || len(args) < 2 {
+			replyMessage(client, v, usage)
+			return
+		}
+		on := strings.ToLower(args[1]) == "on"
+		n = n.clone()
+		switch strings.ToLower(args[0]) {
+		case "bans", "ban":
+			n.ShareBans = on
+		case "mutes", "mute":
+			n.ShareMutes = on
+		default:
+			replyMessage(client, v, usage)
+			return
+		}
+		saveNetwork(n)
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "network share", args[0]+" "+onOff(on))
+		replyMessage(client, v, fmt.Sprintf("✅ Network *%s*: shared %s %s", n.Name, strings.ToLower(args[0]), onOff(on)))
+
+	case "filter":
+		n := groupNetwork(s)
+		if n == nil {
+			replyMessage(client, v, "⚠️ This group is not in a network.")
+			return
+		}
+		if len(args) < 2 {
+			list := "None"
+			if len(n.Filters) > 0 {
+				list = strings.Join(n.Filters, ", ")
+			}
+			replyMessage(client, v, "🧹 *Network filter:* "+list+"\n.network filter add|del <word>")
+			return
+		}
+		word := strings.ToLower(strings.Join(args[1:], " "))
+		add := strings.ToLower(args[0]) == "add"
+		var changed bool
+		n = n.clone()
+		n.Filters, changed = toggleList(n.Filters, word, add)
+		saveNetwork(n)
+		if changed {
+			action := "network filter del"
+			if add {
+				action = "network filter add"
+			}
+			logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, action, word)
+		}
+		replyMessage(client, v, fmt.Sprintf("✅ Network filter: %d word(s)", len(n.Filters)))
+
+	case "info", "":
+		n := groupNetwork(s)
+		if n == nil {
+			replyMessage(client, v, usage)
+			return
+		}
+		var groups []string
+		for _, g := range n.Groups {
+			label := g
+			if jid, err := types.ParseJID(g); err == nil {
+				if info, err := client.GetGroupInfo(context.Background(), jid); err == nil {
+					label = info.Name
+				}
+			}
+			groups = append(groups, label)
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🕸️ NETWORK: %s
+╠════════════════╣
+║ 👥 Groups: %d
+║ %s
+╠════════════════╣
+║ 🚫 Shared bans: %s (%d)
+║ 🔇 Shared mutes: %s (%d)
+║ 🧹 Filter words: %d
+╚════════════════╝`, n.Name, len(n.Groups), strings.Join(groups, "\n║ "),
+			onOff(n.ShareBans), len(n.Banned), onOff(n.ShareMutes), len(n.Muted), len(n.Filters)))
+
+	default:
+		replyMessage(client, v, usage)
+	}
+}
+
+// unlinkGroup گروپ کو موجودہ نیٹ ورک سے ہٹاتا ہے (سیٹنگز کالر سیو کرے)
+func unlinkGroup(s *GroupSettings) {
+	if n := groupNetwork(s); n != nil {
+		n = n.clone()
+		n.Groups, _ = toggleList(n.Groups, s.ChatID, false)
+		saveNetwork(n)
+	}
+	s.Network = ""
+}
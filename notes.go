@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 📝 GROUP NOTES
+// .save <name> (reply or text) / .get <name> or #name / .notes / .clear <name>
+// Stored per group in the Redis hash notes:<bot>:<chat> (name -> Note JSON).
+// Media is kept as a StoredMedia upload reference, so nothing is re-downloaded.
+
+const maxNotesPerGroup = 100
+
+type Note struct {
+	Text  string       `json:"text,omitempty"`
+	Media *StoredMedia `json:"media,omitempty"`
+	By    string       `json:"by"`
+	Time  int64        `json:"time"`
+}
+
+func notesKey(botID, chat string) string {
+	return "notes:" + botID + ":" + chat
+}
+
+// normalizeNoteName صرف حروف، نمبر، _ اور -
+func normalizeNoteName(name string) (string, bool) {
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+	if name == "" || len(name) > 32 {
+		return "", false
+	}
+	for _, c := range name {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9' || c == '_' || c == '-') {
+			return "", false
+		}
+	}
+	return name, true
+}
+
+func loadNote(botID, chat, name string) (*Note, bool) {
+	if rdb == nil {
+		return nil, false
+	}
+	raw, err := rdb.HGet(ctx, notesKey(botID, chat), name).Result()
+	if err != nil {
+		return nil, false
+	}
+	var n Note
+	if json.Unmarshal([]byte(raw), &n) != nil {
+		return nil, false
+	}
+	return &n, true
+}
+
+// sendNote نوٹ کو درخواست کرنے والے میسج کے ریپلائی میں بھیجتا ہے
+func sendNote(client *whatsmeow.Client, v *events.Message, n *Note) {
+	ci := &waProto.ContextInfo{
+		StanzaID:      proto.String(v.Info.ID),
+		Participant:   proto.String(v.Info.Sender.String()),
+		QuotedMessage: v.Message,
+	}
+	if n.Media != nil {
+		_, err := client.SendMessage(context.Background(), v.Info.Chat, n.Media.toMessage(n.Text, ci))
+		if err == nil {
+			return
+		}
+		fmt.Printf("⚠️ [NOTES] Media send failed: %v\n", err)
+		if n.Text == "" {
+			replyMessage(client, v, "❌ Saved media is no longer available. Save it again.")
+			return
+		}
+	}
+	client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:        proto.String(n.Text),
+			ContextInfo: ci,
+		},
+	})
+}
+
+// ---------------------------------------------------------
+// 📝 COMMAND: .save <name> [text]  (or reply to text/media)
+// ---------------------------------------------------------
+func handleSaveNote(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !hasGroupPermission(client, v, "save") {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Redis not connected.")
+		return
+	}
+	if len(args) == 0 {
+		replyMessage(client, v, "⚠️ Usage: reply with .save <name>\nor .save <name> <text>")
+		return
+	}
+	name, ok := normalizeNoteName(args[0])
+	if !ok {
+		replyMessage(client, v, "❌ Name can only use a-z, 0-9, _ and - (max 32).")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	chat := v.Info.Chat.String()
+	key := notesKey(botID, chat)
+
+	if exists, _ := rdb.HExists(ctx, key, name).Result(); !exists {
+		if n, _ := rdb.HLen(ctx, key).Result(); n >= maxNotesPerGroup {
+			replyMessage(client, v, fmt.Sprintf("❌ This group already has %d notes. Clear some first.", maxNotesPerGroup))
+			return
+		}
+	}
+
+	note := Note{By: v.Info.Sender.ToNonAD().String(), Time: time.Now().Unix()}
+
+	// نام کے بعد والا ٹیکسٹ (نئی لائنز سمیت)
+	body := getCommandBody(v)
+	if idx := strings.IndexAny(body, " \n"); idx >= 0 {
+		note.Text = strings.TrimSpace(body[idx+1:])
+	}
+
+	if ci := getContextInfo(v.Message); ci != nil && ci.QuotedMessage != nil {
+		q := ci.QuotedMessage
+		if note.Text == "" {
+			note.Text = getText(q)
+		}
+		if q.ImageMessage != nil || q.VideoMessage != nil || q.StickerMessage != nil || q.AudioMessage != nil || q.DocumentMessage != nil {
+			media, err := captureMedia(client, q)
+			if err != nil {
+				replyMessage(client, v, "❌ Could not save the media: "+err.Error())
+				return
+			}
+			note.Media = media
+		}
+	}
+
+	if note.Text == "" && note.Media == nil {
+		replyMessage(client, v, "⚠️ Nothing to save. Reply to a message or add text after the name.")
+		return
+	}
+
+	data, _ := json.Marshal(note)
+	rdb.HSet(ctx, key, name, data)
+	logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "note save", name)
+
+	kind := "text"
+	if note.Media != nil {
+		kind = note.Media.Kind
+	}
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 📝 NOTE SAVED
+╠════════════════╣
+║ 🏷️ %s (%s)
+║ Get it: #%s
+╚════════════════╝`, name, kind, name))
+}
+
+// ---------------------------------------------------------
+// 📝 COMMAND: .get <name>
+// ---------------------------------------------------------
+func handleGetNote(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if len(args) == 0 {
+		replyMessage(client, v, "⚠️ Usage: .get <name>  (or #name)")
+		return
+	}
+	name, _ := normalizeNoteName(args[0])
+	n, ok := loadNote(getCleanID(client.Store.ID.User), v.Info.Chat.String(), name)
+	if !ok {
+		replyMessage(client, v, "❌ No note named *"+args[0]+"*. See .notes")
+		return
+	}
+	sendNote(client, v, n)
+}
+
+// handleNoteShorthand "#name" والا میسج؛ نوٹ نہ ملے تو false
+func handleNoteShorthand(client *whatsmeow.Client, v *events.Message, body string) bool {
+	if !v.Info.IsGroup || !strings.HasPrefix(body, "#") || strings.ContainsAny(body, " \n") {
+		return false
+	}
+	name, ok := normalizeNoteName(body)
+	if !ok {
+		return false
+	}
+	botID := getCleanID(client.Store.ID.User)
+	chat := v.Info.Chat.String()
+	n, ok := loadNote(botID, chat, name)
+	if !ok {
+		return false
+	}
+	if !canExecute(client, v, "get") {
+		return true
+	}
+	if !isOwner(client, v.Info.Sender) && isCommandDisabled(getGroupSettings(botID, chat), "get") {
+		return true
+	}
+	sendNote(client, v, n)
+	return true
+}
+
+// ---------------------------------------------------------
+// 📝 COMMAND: .notes
+// ---------------------------------------------------------
+func handleListNotes(client *whatsmeow.Client, v *events.Message) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Redis not connected.")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	all, _ := rdb.HGetAll(ctx, notesKey(botID, v.Info.Chat.String())).Result()
+
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		var n Note
+		icon := "📄"
+		if json.Unmarshal([]byte(all[name]), &n) == nil && n.Media != nil {
+			icon = "🖼️"
+		}
+		lines = append(lines, icon+" #"+name)
+	}
+	if len(lines) == 0 {
+		lines = []string{"No notes yet"}
+	}
+
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 📝 NOTES (%d)
+╠════════════════╣
+║ %s
+╠════════════════╣
+║ .get <name> or #name
+╚════════════════╝`, len(all), strings.Join(lines, "\n║ ")))
+}
+
+// ---------------------------------------------------------
+// 📝 COMMAND: .clear <name>
+// ---------------------------------------------------------
+func handleClearNote(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !hasGroupPermission(client, v, "clear") {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+	if rdb == nil {
+		replyMessage(client, v, "❌ Redis not connected.")
+		return
+	}
+	if len(args) == 0 {
+		replyMessage(client, v, "⚠️ Usage: .clear <name>")
+		return
+	}
+	name, _ := normalizeNoteName(args[0])
+	botID := getCleanID(client.Store.ID.User)
+	if n, _ := rdb.HDel(ctx, notesKey(botID, v.Info.Chat.String()), name).Result(); n == 0 {
+		replyMessage(client, v, "❌ No note named *"+args[0]+"*.")
+		return
+	}
+	logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "note clear", name)
+	replyMessage(client, v, "🗑️ Note *"+name+"* cleared.")
+}
@@ -0,0 +1,280 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 📊 NATIVE POLLS
+// Poll meta:   poll:<bot>:<pollMsgID>        JSON PollState
+// Votes:       poll_votes:<bot>:<pollMsgID>  hash voter -> JSON []option
+// A vote message always carries the voter's full selection, so each vote
+// simply replaces the previous one (empty = vote removed).
+
+const pollTTL = 30 * 24 * time.Hour
+
+type PollState struct {
+	Chat      string   `json:"chat"`
+	Question  string   `json:"question"`
+	Options   []string `json:"options"`
+	Multi     bool     `json:"multi"`
+	Creator   string   `json:"creator"`
+	Closed    bool     `json:"closed"`
+	CreatedAt int64    `json:"created_at"`
+}
+
+func pollKey(botID, id string) string      { return "poll:" + botID + ":" + id }
+func pollVotesKey(botID, id string) string { return "poll_votes:" + botID + ":" + id }
+
+func loadPoll(botID, id string) *PollState {
+	if rdb == nil || id == "" {
+		return nil
+	}
+	data, err := rdb.Get(ctx, pollKey(botID, id)).Bytes()
+	if err != nil {
+		return nil
+	}
+	var p PollState
+	if json.Unmarshal(data, &p) != nil {
+		return nil
+	}
+	return &p
+}
+
+func savePoll(botID, id string, p *PollState) {
+	if rdb == nil {
+		return
+	}
+	data, _ := json.Marshal(p)
+	rdb.Set(ctx, pollKey(botID, id), data, pollTTL)
+}
+
+// tallyPoll ہر آپشن کے ووٹ اور کل ووٹرز
+func tallyPoll(botID, id string, p *PollState) (map[string]int, int) {
+	counts := make(map[string]int, len(p.Options))
+	voters := 0
+	if rdb == nil {
+		return counts, 0
+	}
+	raw, _ := rdb.HGetAll(ctx, pollVotesKey(botID, id)).Result()
+	for _, v := range raw {
+		var sel []string
+		if json.Unmarshal([]byte(v), &sel) != nil || len(sel) == 0 {
+			continue
+		}
+		voters++
+		for _, o := range sel {
+			counts[o]++
+		}
+	}
+	return counts, voters
+}
+
+func formatPollResult(p *PollState, counts map[string]int, voters int, final bool) string {
+	title := "📊 POLL RESULTS"
+	if final {
+		title = "🏁 POLL CLOSED"
+	}
+
+	opts := append([]string(nil), p.Options...)
+	if final {
+		sort.SliceStable(opts, func(i, j int) bool { return counts[opts[i]] > counts[opts[j]] })
+	}
+
+	var sb strings.Builder
+	for _, o := range opts {
+		pct := 0
+		if voters > 0 {
+			pct = counts[o] * 100 / voters
+		}
+		bar := strings.Repeat("█", pct/10) + strings.Repeat("░", 10-pct/10)
+		sb.WriteString(fmt.Sprintf("║ %s\n║ %s %d (%d%%)\n", o, bar, counts[o], pct))
+	}
+
+	mode := "Single choice"
+	if p.Multi {
+		mode = "Multiple choice"
+	}
+	return fmt.Sprintf(`╔════════════════╗
+║ %s
+╠════════════════╣
+║ ❓ %s
+╠════════════════╣
+%s╠════════════════╣
+║ 👥 Voters: %d
+║ ☑️ %s
+╚════════════════╝`, title, p.Question, sb.String(), voters, mode)
+}
+
+// handlePollVote poll update کو ڈکرپٹ کر کے ووٹ محفوظ کرتا ہے
+func handlePollVote(client *whatsmeow.Client, v *events.Message) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("⚠️ [POLL] Panic: %v\n", r)
+		}
+	}()
+
+	upd := v.Message.GetPollUpdateMessage()
+	pollID := upd.GetPollCreationMessageKey().GetID()
+	botID := getCleanID(client.Store.ID.User)
+
+	p := loadPoll(botID, pollID)
+	if p == nil || p.Closed {
+		return // ہمارا پول نہیں یا بند ہو چکا
+	}
+
+	vote, err := client.DecryptPollVote(context.Background(), v)
+	if err != nil {
+		fmt.Printf("⚠️ [POLL] Decrypt failed: %v\n", err)
+		return
+	}
+
+	hashes := whatsmeow.HashPollOptions(p.Options)
+	var selected []string
+	for _, h := range vote.GetSelectedOptions() {
+		for i, oh := range hashes {
+			if bytes.Equal(h, oh) {
+				selected = append(selected, p.Options[i])
+				break
+			}
+		}
+	}
+
+	voter := v.Info.Sender.ToNonAD().String()
+	key := pollVotesKey(botID, pollID)
+	if len(selected) == 0 {
+		rdb.HDel(ctx, key, voter)
+		return
+	}
+	data, _ := json.Marshal(selected)
+	rdb.HSet(ctx, key, voter, data)
+	rdb.Expire(ctx, key, pollTTL)
+}
+
+// ---------------------------------------------------------
+// 📊 COMMAND: .poll [multi] Question | option1 | option2 ...
+// ---------------------------------------------------------
+func handlePoll(client *whatsmeow.Client, v *events.Message) {
+	usage := `╔════════════════╗
+║ 📊 POLL
+╠════════════════╣
+║ .poll "Question" | A | B
+║ .poll multi "Q" | A | B | C
+║ .pollresult (reply)
+║ .pollclose (reply)
+╚════════════════╝`
+
+	body := getCommandBody(v)
+	multi := false
+	if lower := strings.ToLower(body); strings.HasPrefix(lower, "multi ") || strings.HasPrefix(lower, "multi\n") {
+		multi = true
+		body = strings.TrimSpace(body[len("multi"):])
+	}
+
+	parts := strings.Split(body, "|")
+	if len(parts) < 3 {
+		replyMessage(client, v, usage)
+		return
+	}
+
+	question := strings.Trim(strings.TrimSpace(parts[0]), "\"“”")
+	var options []string
+	seen := make(map[string]bool)
+	for _, o := range parts[1:] {
+		o = strings.TrimSpace(o)
+		if o == "" || seen[o] {
+			continue
+		}
+		seen[o] = true
+		options = append(options, o)
+	}
+	if question == "" || len(options) < 2 || len(options) > 12 {
+		replyMessage(client, v, "⚠️ A poll needs a question and 2-12 unique options.\n\n"+usage)
+		return
+	}
+
+	selectable := 1
+	if multi {
+		selectable = 0 // 0 = جتنے چاہیں
+	}
+
+	resp, err := client.SendMessage(context.Background(), v.Info.Chat, client.BuildPollCreation(question, options, selectable))
+	if err != nil {
+		replyMessage(client, v, "❌ Failed to create poll: "+err.Error())
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	savePoll(botID, resp.ID, &PollState{
+		Chat:      v.Info.Chat.String(),
+		Question:  question,
+		Options:   options,
+		Multi:     multi,
+		Creator:   v.Info.Sender.ToNonAD().String(),
+		CreatedAt: time.Now().Unix(),
+	})
+}
+
+// quotedPoll ریپلائی کیے گئے پول کی ID اور اسٹیٹ
+func quotedPoll(client *whatsmeow.Client, v *events.Message) (string, *PollState) {
+	ci := getContextInfo(v.Message)
+	if ci == nil || ci.GetStanzaID() == "" {
+		return "", nil
+	}
+	botID := getCleanID(client.Store.ID.User)
+	return ci.GetStanzaID(), loadPoll(botID, ci.GetStanzaID())
+}
+
+func handlePollResult(client *whatsmeow.Client, v *events.Message) {
+	id, p := quotedPoll(client, v)
+	if p == nil {
+		replyMessage(client, v, "⚠️ Reply to a poll created by the bot with .pollresult")
+		return
+	}
+	botID := getCleanID(client.Store.ID.User)
+	counts, voters := tallyPoll(botID, id, p)
+	replyMessage(client, v, formatPollResult(p, counts, voters, p.Closed))
+}
+
+func handlePollClose(client *whatsmeow.Client, v *events.Message) {
+	id, p := quotedPoll(client, v)
+	if p == nil {
+		replyMessage(client, v, "⚠️ Reply to a poll created by the bot with .pollclose")
+		return
+	}
+
+	isCreator := p.Creator == v.Info.Sender.ToNonAD().String()
+	if !isCreator && !isOwner(client, v.Info.Sender) && !(v.Info.IsGroup && isAdmin(client, v.Info.Chat, v.Info.Sender)) {
+		replyMessage(client, v, "❌ Only the poll creator or an admin can close it.")
+		return
+	}
+	if p.Closed {
+		replyMessage(client, v, "⚠️ This poll is already closed.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	p.Closed = true
+	savePoll(botID, id, p)
+
+	counts, voters := tallyPoll(botID, id, p)
+	client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text: proto.String(formatPollResult(p, counts, voters, true)),
+			ContextInfo: &waProto.ContextInfo{
+				StanzaID:    proto.String(id),
+				Participant: proto.String(client.Store.ID.ToNonAD().String()),
+			},
+		},
+	})
+}
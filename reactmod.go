@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// 👆 REACTION MODERATION
+// Admins (or members whose role allows the command) react to a message
+// instead of replying with .del / .warn / .kick. The emoji → action map is
+// per group (GroupSettings.ReactActions); nil means defaultReactActions.
+// The action is logged once, after it succeeded, with the reactor as actor and
+// the same name the command uses, so .undo works on it.
+
+var defaultReactActions = map[string]string{
+	"🗑": "delete",
+	"⚠": "warn",
+	"🚫": "kick",
+}
+
+// reactActionCmd ایکشن → پرمیشن والی کمانڈ
+var reactActionCmd = map[string]string{
+	"delete": "del",
+	"warn":   "warn",
+	"kick":   "kick",
+}
+
+// normalizeReactEmoji variation selector ہٹا دیں (🗑️ اور 🗑 ایک ہی ہیں)
+func normalizeReactEmoji(e string) string {
+	return strings.TrimSpace(strings.ReplaceAll(e, "\ufe0f", ""))
+}
+
+func reactActions(s *GroupSettings) map[string]string {
+	if s.ReactActions == nil {
+		return defaultReactActions
+	}
+	return s.ReactActions
+}
+
+func handleReactionModeration(client *whatsmeow.Client, v *events.Message) {
+	if !v.Info.IsGroup || v.Info.IsFromMe {
+		return
+	}
+	r := v.Message.GetReactionMessage()
+	emoji := normalizeReactEmoji(r.GetText())
+	if emoji == "" {
+		return // ری ایکشن ہٹایا گیا
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	if !s.ReactMod {
+		return
+	}
+	action := reactActions(s)[emoji]
+	cmd, ok := reactActionCmd[action]
+	if !ok || !hasGroupPermission(client, v, cmd) {
+		return
+	}
+
+	key := r.GetKey()
+	chat := v.Info.Chat
+
+	// بوٹ کا اپنا میسج: صرف ڈیلیٹ
+	if key.GetFromMe() {
+		if action == "delete" {
+			client.SendMessage(context.Background(), chat, client.BuildRevoke(chat, types.EmptyJID, key.GetID()))
+		}
+		return
+	}
+
+	target, err := types.ParseJID(key.GetParticipant())
+	if err != nil || target.User == "" {
+		return
+	}
+	if action != "delete" && (isAdmin(client, chat, target) || isOwner(client, target)) {
+		replyMessage(client, v, "❌ Admins can't be "+map[string]string{"warn": "warned", "kick": "kicked"}[action]+" by reaction.")
+		return
+	}
+
+	// پہلے میسج ہٹائیں؛ ناکام ہو تو بوٹ ایڈمن نہیں، آگے کچھ نہیں
+	_, err = client.SendMessage(context.Background(), chat, client.BuildRevoke(chat, target, key.GetID()))
+	if err != nil {
+		replyMessage(client, v, "⚠️ Failed to Delete (Give me Admin Rights)")
+		return
+	}
+	reason := "reaction " + r.GetText()
+
+	switch action {
+	case "delete":
+		logModAction(client, chat, v.Info.Sender, target, "delete", reason)
+
+	case "warn":
+		logModAction(client, chat, v.Info.Sender, target, "warn", reason)
+		autoKick := isAdmin(client, chat, v.Info.Sender) || isOwner(client, v.Info.Sender)
+		applyWarning(client, chat, target, "Admin reaction", autoKick)
+
+	case "kick":
+		res, err := client.UpdateGroupParticipants(context.Background(), chat, []types.JID{target}, whatsmeow.ParticipantChangeRemove)
+		if err == nil && len(res) > 0 && res[0].Error != 0 {
+			err = fmt.Errorf("WhatsApp refused (code %d)", res[0].Error)
+		}
+		if err != nil {
+			replyMessage(client, v, "⚠️ Message deleted, kick failed: "+explainGroupError(err))
+			return
+		}
+		logModAction(client, chat, v.Info.Sender, target, "kick", reason)
+		sendGroupNotice(client, chat, target, fmt.Sprintf(`╔════════════════╗
+║ 👢 KICKED
+╠════════════════╣
+║ User: @%s
+║ Reason: Admin reaction
+╚════════════════╝`, target.User))
+	}
+}
+
+// ---------------------------------------------------------
+// 👆 COMMAND: .reactmod on|off | set <emoji> <delete|warn|kick|off> | reset
+// ---------------------------------------------------------
+func handleReactMod(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	switch sub {
+	case "on", "off":
+		s.ReactMod = sub == "on"
+		saveGroupSettings(botID, s)
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "reactmod", sub)
+
+	case "set":
+		if len(args) < 3 {
+			replyMessage(client, v, "⚠️ Usage: .reactmod set <emoji> <delete|warn|kick|off>")
+			return
+		}
+		emoji := normalizeReactEmoji(args[1])
+		action := strings.ToLower(args[2])
+		if _, ok := reactActionCmd[action]; !ok && action != "off" {
+			replyMessage(client, v, "❌ Action must be delete, warn, kick or off.")
+			return
+		}
+		// نئی میپ (پہلی بار ڈیفالٹس سے)؛ شیئرڈ میپ ری ایکشن ہینڈلر پڑھ رہا ہوتا ہے
+		actions := cloneMap(reactActions(s))
+		if action == "off" {
+			delete(actions, emoji)
+		} else {
+			actions[emoji] = action
+		}
+		s.ReactActions = actions
+		saveGroupSettings(botID, s)
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "reactmod set", emoji+" "+action)
+
+	case "reset":
+		s.ReactActions = nil
+		saveGroupSettings(botID, s)
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "reactmod reset", "")
+	}
+
+	actions := reactActions(s)
+	emojis := make([]string, 0, len(actions))
+	for e := range actions {
+		emojis = append(emojis, e)
+	}
+	sort.Strings(emojis)
+	var lines []string
+	for _, e := range emojis {
+		lines = append(lines, fmt.Sprintf("%s → %s", e, actions[e]))
+	}
+	if len(lines) == 0 {
+		lines = []string{"No reactions mapped"}
+	}
+
+	replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 👆 REACTION MOD
+╠════════════════╣
+║ Status: %s
+║ %s
+╠════════════════╣
+║ .reactmod on/off
+║ .reactmod set 🗑️ delete
+║ .reactmod set 👎 off
+║ .reactmod reset
+╚════════════════╝`, onOff(s.ReactMod), strings.Join(lines, "\n║ ")))
+}
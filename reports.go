@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 🚩 MEMBER REPORTS
+// .report (reply) sends the reported message to the group admins' DMs, or to
+// a moderation group set with .reportto. Every message the bot sends for a
+// report is registered in Redis so an admin can reply to it with .kick or
+// .warn and the bot knows which group and user it belongs to.
+
+const reportTTL = 7 * 24 * time.Hour
+
+type ReportEntry struct {
+	Chat       string   `json:"chat"`
+	Target     string   `json:"target"`
+	MessageID  string   `json:"message_id"`
+	Reporter   string   `json:"reporter"`
+	Reason     string   `json:"reason"`
+	Recipients []string `json:"recipients"` // جن ایڈمنز کو DM گیا (clean IDs)
+}
+
+func reportKey(botID, msgID string) string {
+	return "report:" + botID + ":" + msgID
+}
+
+func saveReportRef(botID, msgID string, r *ReportEntry) {
+	if rdb == nil || msgID == "" {
+		return
+	}
+	data, _ := json.Marshal(r)
+	rdb.Set(ctx, reportKey(botID, msgID), data, reportTTL)
+}
+
+func loadReportRef(botID, msgID string) *ReportEntry {
+	if rdb == nil || msgID == "" {
+		return nil
+	}
+	data, err := rdb.Get(ctx, reportKey(botID, msgID)).Bytes()
+	if err != nil {
+		return nil
+	}
+	var r ReportEntry
+	if json.Unmarshal(data, &r) != nil {
+		return nil
+	}
+	return &r
+}
+
+// ---------------------------------------------------------
+// 🚩 COMMAND: .report [reason]  (reply to a message)
+// ---------------------------------------------------------
+func handleReport(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+
+	ci := getContextInfo(v.Message)
+	if ci == nil || ci.QuotedMessage == nil || ci.GetStanzaID() == "" || ci.GetParticipant() == "" {
+		replyMessage(client, v, "⚠️ Reply to a message with .report [reason]")
+		return
+	}
+
+	target, err := types.ParseJID(ci.GetParticipant())
+	if err != nil {
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	reason := strings.TrimSpace(strings.Join(args, " "))
+	if reason == "" {
+		reason = "No reason given"
+	}
+
+	// رپورٹر کو چھپانے کے لیے کمانڈ میسج فوراً ڈیلیٹ
+	client.SendMessage(context.Background(), v.Info.Chat, client.BuildRevoke(v.Info.Chat, v.Info.Sender, v.Info.ID))
+
+	info, err := client.GetGroupInfo(context.Background(), v.Info.Chat)
+	if err != nil {
+		return
+	}
+
+	entry := &ReportEntry{
+		Chat:      v.Info.Chat.String(),
+		Target:    target.String(),
+		MessageID: ci.GetStanzaID(),
+		Reporter:  v.Info.Sender.String(),
+		Reason:    reason,
+	}
+
+	link := "(bot is not admin)"
+	if code, err := client.GetGroupInviteLink(context.Background(), v.Info.Chat, false); err == nil && code != "" {
+		link = code
+		if !strings.HasPrefix(code, "http") {
+			link = "https://chat.whatsapp.com/" + code
+		}
+	}
+
+	header := fmt.Sprintf(`╔════════════════╗
+║ 🚩 NEW REPORT
+╠════════════════╣
+║ 👥 %s
+║ 👤 User: @%s
+║ 🙋 By: @%s
+║ 📝 %s
+║ 🔗 %s
+╠════════════════╣
+║ Reply to this with
+║ .kick or .warn
+╚════════════════╝`, info.Name, target.User, v.Info.Sender.User, reason, link)
+
+	// کہاں بھیجنا ہے؟ ماڈریشن گروپ یا ہر ایڈمن کا DM
+	var destinations []types.JID
+	if s.ReportGroup != "" {
+		if jid, err := types.ParseJID(s.ReportGroup); err == nil {
+			destinations = append(destinations, jid)
+		}
+	} else {
+		for _, p := range info.Participants {
+			if !p.IsAdmin && !p.IsSuperAdmin {
+				continue
+			}
+			if client.Store.ID != nil && (p.JID.User == client.Store.ID.User || p.PhoneNumber.User == client.Store.ID.User) {
+				continue
+			}
+			if !client.Store.LID.IsEmpty() && p.JID.User == client.Store.LID.User {
+				continue
+			}
+			dest := p.JID
+			if !p.PhoneNumber.IsEmpty() {
+				dest = p.PhoneNumber
+			}
+			destinations = append(destinations, dest)
+			entry.Recipients = append(entry.Recipients, getCleanID(p.JID.User))
+			if !p.PhoneNumber.IsEmpty() {
+				entry.Recipients = append(entry.Recipients, getCleanID(p.PhoneNumber.User))
+			}
+			if !p.LID.IsEmpty() {
+				entry.Recipients = append(entry.Recipients, getCleanID(p.LID.User))
+			}
+		}
+	}
+
+	if len(destinations) == 0 {
+		return
+	}
+
+	mentions := []string{target.String(), v.Info.Sender.String()}
+	for _, dest := range destinations {
+		resp, err := client.SendMessage(context.Background(), dest, &waProto.Message{
+			ExtendedTextMessage: &waProto.ExtendedTextMessage{
+				Text:        proto.String(header),
+				ContextInfo: &waProto.ContextInfo{MentionedJID: mentions},
+			},
+		})
+		if err != nil {
+			fmt.Printf("⚠️ [REPORT] Send to %s failed: %v\n", dest.String(), err)
+			continue
+		}
+		saveReportRef(botID, resp.ID, entry)
+
+		// اصل میسج بھی فارورڈ کریں
+		fwd := proto.Clone(ci.QuotedMessage).(*waProto.Message)
+		if resp, err := client.SendMessage(context.Background(), dest, fwd); err == nil {
+			saveReportRef(botID, resp.ID, entry)
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+// handleReportAction رپورٹ پر ریپلائی کر کے .kick / .warn (DM یا موڈریشن گروپ)
+// true واپس کرتا ہے اگر میسج رپورٹ سے متعلق تھا
+func handleReportAction(client *whatsmeow.Client, v *events.Message, action string) bool {
+	ci := getContextInfo(v.Message)
+	if ci == nil || ci.GetStanzaID() == "" {
+		return false
+	}
+	botID := getCleanID(client.Store.ID.User)
+	r := loadReportRef(botID, ci.GetStanzaID())
+	if r == nil {
+		return false
+	}
+
+	chat, err1 := types.ParseJID(r.Chat)
+	target, err2 := types.ParseJID(r.Target)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+
+	allowed := isOwner(client, v.Info.Sender) || isAdmin(client, chat, v.Info.Sender)
+	sender := getCleanID(v.Info.Sender.User)
+	for _, u := range r.Recipients {
+		if u == sender {
+			allowed = true
+		}
+	}
+	if !allowed {
+		replyMessage(client, v, "❌ You are not an admin of that group.")
+		return true
+	}
+
+	// رپورٹ شدہ میسج گروپ سے ہٹا دیں
+	client.SendMessage(context.Background(), chat, client.BuildRevoke(chat, target, r.MessageID))
+
+	switch action {
+	case "kick":
+		res, err := client.UpdateGroupParticipants(context.Background(), chat, []types.JID{target}, whatsmeow.ParticipantChangeRemove)
+		if err == nil && len(res) > 0 && res[0].Error != 0 {
+			err = fmt.Errorf("WhatsApp refused (code %d)", res[0].Error)
+		}
+		if err != nil {
+			replyMessage(client, v, "⚠️ Kick failed: "+explainGroupError(err))
+			return true
+		}
+		sendGroupNotice(client, chat, target, fmt.Sprintf(`╔════════════════╗
+║ 👢 KICKED
+╠════════════════╣
+║ User: @%s
+║ Reason: Reported
+╚════════════════╝`, target.User))
+		logModAction(client, chat, v.Info.Sender, target, "kick", "reported message")
+		replyMessage(client, v, "✅ User kicked and message deleted.")
+
+	case "warn":
+		logModAction(client, chat, v.Info.Sender, target, "warn", r.Reason)
+		count, kicked := applyWarning(client, chat, target, r.Reason, true)
+		if kicked {
+			replyMessage(client, v, "✅ 3/3 warnings, user kicked.")
+		} else {
+			replyMessage(client, v, fmt.Sprintf("✅ Warned (%d/3) and message deleted.", count))
+		}
+	}
+	return true
+}
+
+// sendGroupNotice گروپ میں مینشن کے ساتھ نوٹس
+func sendGroupNotice(client *whatsmeow.Client, chat, user types.JID, text string) {
+	client.SendMessage(context.Background(), chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:        proto.String(text),
+			ContextInfo: &waProto.ContextInfo{MentionedJID: []string{user.String()}},
+		},
+	})
+}
+
+// ---------------------------------------------------------
+// ⚙️ COMMAND: .reportto dm | <group-id>
+// ---------------------------------------------------------
+func handleReportTo(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	if len(args) == 0 {
+		dest := "Admin DMs"
+		if s.ReportGroup != "" {
+			dest = s.ReportGroup
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🚩 REPORTS
+╠════════════════╣
+║ 📬 %s
+╠════════════════╣
+║ .reportto dm
+║ .reportto <group-id>
+╚════════════════╝`, dest))
+		return
+	}
+
+	if strings.ToLower(args[0]) == "dm" {
+		s.ReportGroup = ""
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ Reports will be sent to admin DMs.")
+		return
+	}
+
+	id := args[0]
+	if !strings.Contains(id, "@") {
+		id += "@" + types.GroupServer
+	}
+	jid, err := types.ParseJID(id)
+	if err != nil || jid.Server != types.GroupServer {
+		replyMessage(client, v, "❌ Invalid group ID. Use .id inside the mod group.")
+		return
+	}
+	if _, err := client.GetGroupInfo(context.Background(), jid); err != nil {
+		replyMessage(client, v, "❌ Bot is not a member of that group.")
+		return
+	}
+
+	s.ReportGroup = jid.String()
+	saveGroupSettings(botID, s)
+	replyMessage(client, v, "✅ Reports will be sent to the moderation group.")
+}
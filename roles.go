@@ -0,0 +1,410 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 🎖️ CUSTOM ROLES
+// Trusted members get a per-group role that unlocks a fixed set of
+// moderation commands without making them WhatsApp admins.
+// Roles:   GroupSettings.Roles        role -> commands (overrides defaults)
+// Members: GroupSettings.MemberRoles  cleanID -> role
+// GroupSettings is shared by every message goroutine, so its maps (Roles,
+// MemberRoles, Muted, Warnings) are copy-on-write: never changed in place,
+// a changed copy is assigned back instead.
+
+var defaultRoles = map[string][]string{
+	"moderator": {"warn", "mute", "unmute", "del", "delete", "tagall", "hidetag"},
+	"helper":    {"warn", "del", "delete"},
+}
+
+// cloneMap تبدیلی کے لیے نئی کاپی (nil ہو تو خالی میپ)
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	out := make(map[K]V, len(m)+1)
+	for k, v := range m {
+		out[k] = v
+	}
+	return out
+}
+
+// roleGrantable وہ کمانڈز جو hasGroupPermission سے چیک ہوتی ہیں؛ رول میں صرف یہی دی جا سکتی ہیں
+// (kick صرف ری ایکشن موڈریشن کے لیے)
+var roleGrantable = map[string]bool{
+	"warn": true, "mute": true, "unmute": true, "del": true, "delete": true,
+	"tagall": true, "hidetag": true, "kick": true, "undo": true,
+	"save": true, "clear": true, "autoresponder": true, "addcmd": true, "delcmd": true,
+}
+
+// roleCommands گروپ کا اپنا رول پہلے، ورنہ ڈیفالٹ
+func roleCommands(s *GroupSettings, role string) ([]string, bool) {
+	if cmds, ok := s.Roles[role]; ok {
+		return cmds, true
+	}
+	cmds, ok := defaultRoles[role]
+	return cmds, ok
+}
+
+// memberRole یوزر کا رول (JID یا LID کسی سے بھی)
+func memberRole(s *GroupSettings, ids ...string) string {
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		if r, ok := s.MemberRoles[getCleanID(id)]; ok {
+			return r
+		}
+	}
+	return ""
+}
+
+// roleAllows چیک کرتا ہے کہ بھیجنے والے کا رول یہ کمانڈ چلا سکتا ہے
+func roleAllows(s *GroupSettings, v *events.Message, cmd string) bool {
+	role := memberRole(s, v.Info.Sender.User, v.Info.SenderAlt.User)
+	if role == "" {
+		return false
+	}
+	cmds, _ := roleCommands(s, role)
+	for _, c := range cmds {
+		if c == cmd {
+			return true
+		}
+	}
+	return false
+}
+
+// hasGroupPermission ایڈمن، اونر یا رول رکھنے والا ممبر
+func hasGroupPermission(client *whatsmeow.Client, v *events.Message, cmd string) bool {
+	if isOwner(client, v.Info.Sender) || isAdmin(client, v.Info.Chat, v.Info.Sender) {
+		return true
+	}
+	if !v.Info.IsGroup {
+		return false
+	}
+	botID := getCleanID(client.Store.ID.User)
+	return roleAllows(getGroupSettings(botID, v.Info.Chat.String()), v, cmd)
+}
+
+// ---------------------------------------------------------
+// 🎖️ COMMAND: .role add @user <role> | remove @user | list
+// 🎖️ COMMAND: .role create <name> cmd1,cmd2 | drop <name>
+//
+// ---------------------------------------------------------
+func handleRole(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+		args = args[1:]
+	}
+
+	switch sub {
+	case "add", "set":
+		// .role add @user moderator  (یا ریپلائی کر کے .role add moderator)
+		var target types.JID
+		if len(args) >= 2 {
+			target, _ = getTargetJID(v, args[:1])
+			args = args[1:]
+		} else {
+			target, _ = getTargetJID(v, nil)
+		}
+		if target.User == "" || len(args) == 0 {
+			replyMessage(client, v, "⚠️ Usage: .role add @user <role>")
+			return
+		}
+		role := strings.ToLower(args[0])
+		if _, ok := roleCommands(s, role); !ok {
+			replyMessage(client, v, "❌ Unknown role: "+role+"\nSee .role list")
+			return
+		}
+		roles := cloneMap(s.MemberRoles)
+		roles[getCleanID(target.User)] = role
+		s.MemberRoles = roles
+		saveGroupSettings(botID, s)
+		logModAction(client, v.Info.Chat, v.Info.Sender, target, "role add", role)
+		sendGroupNotice(client, v.Info.Chat, target, fmt.Sprintf(`╔════════════════╗
+║ 🎖️ ROLE ASSIGNED
+╠════════════════╣
+║ 👤 @%s
+║ 🏷️ %s
+╚════════════════╝`, target.User, role))
+
+	case "remove", "rm", "del":
+		target, _ := getTargetJID(v, args)
+		id := getCleanID(target.User)
+		if _, ok := s.MemberRoles[id]; !ok || id == "" {
+			replyMessage(client, v, "⚠️ Usage: .role remove @user (user must have a role)")
+			return
+		}
+		roles := cloneMap(s.MemberRoles)
+		delete(roles, id)
+		s.MemberRoles = roles
+		saveGroupSettings(botID, s)
+		logModAction(client, v.Info.Chat, v.Info.Sender, target, "role remove", "")
+		replyMessage(client, v, "✅ Role removed from @"+target.User)
+
+	case "create":
+		if len(args) < 2 {
+			replyMessage(client, v, "⚠️ Usage: .role create <name> warn,mute,del")
+			return
+		}
+		name := strings.ToLower(args[0])
+		var cmds, invalid []string
+		for _, c := range strings.Split(strings.Join(args[1:], ","), ",") {
+			c = strings.ToLower(strings.TrimSpace(c))
+			switch {
+			case c == "":
+			case roleGrantable[c]:
+				cmds = append(cmds, c)
+			default:
+				invalid = append(invalid, c)
+			}
+		}
+		if len(invalid) > 0 || len(cmds) == 0 {
+			allowed := make([]string, 0, len(roleGrantable))
+			for c := range roleGrantable {
+				allowed = append(allowed, c)
+			}
+			sort.Strings(allowed)
+			replyMessage(client, v, fmt.Sprintf("❌ Roles can't grant: %s\nAllowed: %s", strings.Join(invalid, ", "), strings.Join(allowed, ", ")))
+			return
+		}
+		custom := cloneMap(s.Roles)
+		custom[name] = cmds
+		s.Roles = custom
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ Role *%s* → %s", name, strings.Join(cmds, ", ")))
+
+	case "drop":
+		if len(args) == 0 {
+			replyMessage(client, v, "⚠️ Usage: .role drop <name>")
+			return
+		}
+		name := strings.ToLower(args[0])
+		if _, ok := s.Roles[name]; !ok {
+			replyMessage(client, v, "❌ No custom role named "+name)
+			return
+		}
+		custom := cloneMap(s.Roles)
+		delete(custom, name)
+		s.Roles = custom
+		// اگر ڈیفالٹ رول بھی نہیں تو ممبرز سے ہٹا دیں
+		if _, ok := defaultRoles[name]; !ok {
+			roles := cloneMap(s.MemberRoles)
+			for id, r := range roles {
+				if r == name {
+					delete(roles, id)
+				}
+			}
+			s.MemberRoles = roles
+		}
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ Role *"+name+"* removed")
+
+	default:
+		names := make(map[string]bool)
+		for r := range defaultRoles {
+			names[r] = true
+		}
+		for r := range s.Roles {
+			names[r] = true
+		}
+		var roles []string
+		for r := range names {
+			roles = append(roles, r)
+		}
+		sort.Strings(roles)
+
+		var sb strings.Builder
+		for _, r := range roles {
+			cmds, _ := roleCommands(s, r)
+			sb.WriteString(fmt.Sprintf("║ 🏷️ *%s*: %s\n", r, strings.Join(cmds, ", ")))
+		}
+		sb.WriteString("╠════════════════╣\n")
+		if len(s.MemberRoles) == 0 {
+			sb.WriteString("║ No members assigned\n")
+		}
+		for id, r := range s.MemberRoles {
+			sb.WriteString(fmt.Sprintf("║ 👤 %s → %s\n", id, r))
+		}
+
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🎖️ ROLES
+╠════════════════╣
+%s╠════════════════╣
+║ .role add @user <role>
+║ .role remove @user
+║ .role create <name> cmds
+║ .role drop <name>
+╚════════════════╝`, sb.String()))
+	}
+}
+
+// ---------------------------------------------------------
+// 🔇 MUTE: میوٹ شدہ ممبر کے میسجز خود بخود ڈیلیٹ
+// GroupSettings.Muted  cleanID -> expiry unix (0 = جب تک unmute نہ ہو)
+// ---------------------------------------------------------
+func isMuted(s *GroupSettings, ids ...string) bool {
+	for _, id := range ids {
+		if id == "" {
+			continue
+		}
+		// ختم شدہ میوٹ پر رکیں نہیں: دوسری ID یا نیٹ ورک میوٹ ابھی فعال ہو سکتا ہے
+		if until, ok := s.Muted[getCleanID(id)]; ok && muteActive(until) {
+			return true
+		}
+	}
+	return networkMuted(s, ids...)
+}
+
+func muteActive(until int64) bool {
+	return until == 0 || time.Now().Unix() < until
+}
+
+// pruneExpiredMutes ختم شدہ میوٹس نئی میپ میں ہٹا کر محفوظ کرتا ہے
+func pruneExpiredMutes(botID string, s *GroupSettings) {
+	var expired []string
+	for id, until := range s.Muted {
+		if !muteActive(until) {
+			expired = append(expired, id)
+		}
+	}
+	if len(expired) == 0 {
+		return
+	}
+	muted := cloneMap(s.Muted)
+	for _, id := range expired {
+		delete(muted, id)
+	}
+	s.Muted = muted
+	saveGroupSettings(botID, s)
+}
+
+// handleMutedMember true واپس کرتا ہے اگر میسج ڈیلیٹ کر دیا گیا
+func handleMutedMember(client *whatsmeow.Client, v *events.Message) bool {
+	// revoke/edit جیسے پروٹوکول میسج ممبر کا نیا میسج نہیں
+	if !v.Info.IsGroup || v.Info.IsFromMe || v.Message.GetProtocolMessage() != nil {
+		return false
+	}
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	pruneExpiredMutes(botID, s)
+	if (len(s.Muted) == 0 && s.Network == "") || !isMuted(s, v.Info.Sender.User, v.Info.SenderAlt.User) {
+		return false
+	}
+
+	_, err := client.SendMessage(context.Background(), v.Info.Chat, client.BuildRevoke(v.Info.Chat, v.Info.Sender, v.Info.ID))
+	if err != nil {
+		return false
+	}
+	if shouldNotifyMember(v.Info.Chat.String(), v.Info.Sender.User, "mute", 10*time.Minute) {
+		client.SendMessage(context.Background(), v.Info.Sender.ToNonAD(), &waProto.Message{
+			Conversation: proto.String("🔇 You are muted in this group. Your messages are being removed."),
+		})
+	}
+	return true
+}
+
+// parseMuteDuration "30m" / "2h" / "1d"
+func parseMuteDuration(s string) (time.Duration, bool) {
+	s = strings.ToLower(s)
+	if strings.HasSuffix(s, "d") {
+		days, ok := parseDays(s)
+		return time.Duration(days) * 24 * time.Hour, ok
+	}
+	d, err := time.ParseDuration(s)
+	return d, err == nil && d > 0
+}
+
+func handleMute(client *whatsmeow.Client, v *events.Message, args []string, mute bool) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	cmd := "mute"
+	if !mute {
+		cmd = "unmute"
+	}
+	if !hasGroupPermission(client, v, cmd) {
+		replyMessage(client, v, "❌ Only Admins or Moderators!")
+		return
+	}
+
+	// پہلا آرگ نمبر/مینشن ہو تو ٹارگٹ، باقی مدت
+	var target types.JID
+	if len(args) > 0 && strings.TrimLeft(strings.TrimPrefix(args[0], "@"), "+0123456789") == "" {
+		target, _ = getTargetJID(v, args[:1])
+		args = args[1:]
+	} else {
+		target, _ = getTargetJID(v, nil)
+	}
+	if target.User == "" {
+		replyMessage(client, v, "⚠️ Usage: .mute @user [30m|2h|1d]\n.unmute @user")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	id := getCleanID(target.User)
+
+	if !mute {
+		muted := cloneMap(s.Muted)
+		delete(muted, id)
+		s.Muted = muted
+		saveGroupSettings(botID, s)
+		setNetworkMute(s, target, 0, false)
+		logModAction(client, v.Info.Chat, v.Info.Sender, target, "unmute", "")
+		sendGroupNotice(client, v.Info.Chat, target, "🔊 @"+target.User+" unmuted")
+		return
+	}
+
+	if isAdmin(client, v.Info.Chat, target) {
+		replyMessage(client, v, "❌ Cannot mute an admin.")
+		return
+	}
+
+	var until int64
+	label := "until unmuted"
+	if len(args) > 0 {
+		d, ok := parseMuteDuration(args[0])
+		if !ok {
+			replyMessage(client, v, "⚠️ Duration examples: 30m, 2h, 1d")
+			return
+		}
+		until = time.Now().Add(d).Unix()
+		label = "for " + args[0]
+	}
+
+	muted := cloneMap(s.Muted)
+	muted[id] = until
+	s.Muted = muted
+	saveGroupSettings(botID, s)
+	setNetworkMute(s, target, until, true)
+	logModAction(client, v.Info.Chat, v.Info.Sender, target, "mute", label)
+	sendGroupNotice(client, v.Info.Chat, target, fmt.Sprintf(`╔════════════════╗
+║ 🔇 MUTED
+╠════════════════╣
+║ 👤 @%s
+║ ⏳ %s
+╚════════════════╝`, target.User, label))
+}
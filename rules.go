@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 📜 GROUP RULES
+// Stored in GroupSettings.Rules, shown with .rules, usable as {rules} in
+// welcome templates and optionally DM'd to every new member.
+
+func formatRules(groupName, rules string) string {
+	if groupName == "" {
+		groupName = "this group"
+	}
+	return fmt.Sprintf(`╔════════════════╗
+║ 📜 GROUP RULES
+╠════════════════╣
+║ 👥 %s
+╚════════════════╝
+
+%s`, groupName, rules)
+}
+
+// sendRulesDM نئے ممبرز کو پرائیویٹ میں رولز بھیجتا ہے
+func sendRulesDM(client *whatsmeow.Client, chat types.JID, users []types.JID, s *GroupSettings) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("⚠️ Rules DM Panic: %v\n", r)
+		}
+	}()
+
+	groupName := ""
+	if info, err := client.GetGroupInfo(context.Background(), chat); err == nil {
+		groupName = info.Name
+	}
+	text := formatRules(groupName, s.Rules)
+
+	for _, u := range users {
+		_, err := client.SendMessage(context.Background(), u.ToNonAD(), &waProto.Message{
+			Conversation: proto.String(text),
+		})
+		if err != nil {
+			fmt.Printf("⚠️ [RULES] DM to %s failed: %v\n", u.User, err)
+		}
+		time.Sleep(1 * time.Second) // واٹس ایپ ریٹ لمٹ سے بچنے کے لیے
+	}
+}
+
+// .rules  |  .rules dm on/off
+func handleRules(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	if len(args) > 0 && strings.ToLower(args[0]) == "dm" {
+		if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+			replyMessage(client, v, "❌ Only Admins!")
+			return
+		}
+		mode := ""
+		if len(args) > 1 {
+			mode = strings.ToLower(args[1])
+		}
+		switch mode {
+		case "on":
+			s.RulesDM = true
+			replyMessage(client, v, "✅ *Rules DM:* ON\nNew members will get the rules in private.")
+		case "off":
+			s.RulesDM = false
+			replyMessage(client, v, "❌ *Rules DM:* OFF")
+		default:
+			replyMessage(client, v, "⚠️ Usage: .rules dm on | off")
+			return
+		}
+		saveGroupSettings(botID, s)
+		return
+	}
+
+	if s.Rules == "" {
+		replyMessage(client, v, "📜 No rules set yet.\nAdmins can use .setrules <text>")
+		return
+	}
+
+	groupName := ""
+	if info, err := client.GetGroupInfo(context.Background(), v.Info.Chat); err == nil {
+		groupName = info.Name
+	}
+	replyMessage(client, v, formatRules(groupName, s.Rules))
+}
+
+// .setrules <text>  (یا کسی میسج پر ریپلائی)  |  .setrules clear
+func handleSetRules(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	if len(args) == 1 && strings.ToLower(args[0]) == "clear" {
+		s.Rules = ""
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "🗑️ *Rules Cleared*")
+		return
+	}
+
+	text := getCommandBody(v)
+	if text == "" {
+		text = quotedText(v)
+	}
+	if text == "" {
+		replyMessage(client, v, "⚠️ Usage: .setrules <text>\n(or reply to a message with .setrules)")
+		return
+	}
+
+	s.Rules = text
+	saveGroupSettings(botID, s)
+
+	dm := "OFF"
+	if s.RulesDM {
+		dm = "ON"
+	}
+	replyMessage(client, v, fmt.Sprintf("✅ *Rules Saved*\n📜 View: .rules\n📩 DM to new members: %s (.rules dm on/off)\n💡 Use {rules} in .setwelcome", dm))
+}
@@ -0,0 +1,316 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // ڈوکر امیج میں ٹائم زون ڈیٹا نہ ہو تب بھی چلے
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// ⏰ GROUP SCHEDULER (Night Mode)
+// Schedules are stored in GroupSettings (Redis) and indexed in a Redis set so
+// the loop can find them again after a restart. Each tick applies the most
+// recent close/open transition once; manual .group open/close in between is
+// left alone until the next transition.
+
+const (
+	groupSchedulesKey  = "group_schedules" // members: botID|chatID
+	defaultScheduleTZ  = "Asia/Karachi"
+	schedulerTickEvery = 30 * time.Second
+)
+
+// StartGroupScheduler بیک گراؤنڈ لوپ شروع کرتا ہے
+func StartGroupScheduler() {
+	go func() {
+		fmt.Println("⏰ [SCHEDULER] Group scheduler started")
+		ticker := time.NewTicker(schedulerTickEvery)
+		defer ticker.Stop()
+		for range ticker.C {
+			schedulerStep("schedules", runGroupSchedules)
+			schedulerStep("join policies", runJoinPolicies)
+		}
+	}()
+}
+
+// schedulerStep ایک کام کا panic صرف اسی ٹک تک محدود رہے، لوپ چلتا رہے
+func schedulerStep(name string, fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("⚠️ [SCHEDULER] Panic in %s: %v\n", name, r)
+		}
+	}()
+	fn()
+}
+
+func getClientForBot(botID string) *whatsmeow.Client {
+	clientsMutex.RLock()
+	defer clientsMutex.RUnlock()
+	c, ok := activeClients[botID]
+	if !ok || c == nil || !c.IsConnected() {
+		return nil
+	}
+	return c
+}
+
+func scheduleLocation(s *GroupSettings) *time.Location {
+	tz := s.Timezone
+	if tz == "" {
+		tz = defaultScheduleTZ
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// parseClock "23:00" کو گھنٹے اور منٹ میں بدلتا ہے
+func parseClock(hhmm string) (int, int, bool) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return 0, 0, false
+	}
+	return t.Hour(), t.Minute(), true
+}
+
+// lastOccurrence دیے گئے وقت کی سب سے حالیہ (ماضی کی) تاریخ
+func lastOccurrence(now time.Time, hhmm string) (time.Time, bool) {
+	h, m, ok := parseClock(hhmm)
+	if !ok {
+		return time.Time{}, false
+	}
+	t := time.Date(now.Year(), now.Month(), now.Day(), h, m, 0, 0, now.Location())
+	if t.After(now) {
+		t = t.AddDate(0, 0, -1)
+	}
+	return t, true
+}
+
+// dueScheduleAction بتاتا ہے کہ کون سی ٹرانزیشن ابھی لاگو ہونی ہے ("close" / "open" / "")
+func dueScheduleAction(s *GroupSettings, now time.Time) (string, time.Time) {
+	now = now.In(scheduleLocation(s))
+	lastClose, ok1 := lastOccurrence(now, s.ScheduleClose)
+	lastOpen, ok2 := lastOccurrence(now, s.ScheduleOpen)
+	if !ok1 || !ok2 {
+		return "", time.Time{}
+	}
+
+	action, at := "open", lastOpen
+	if lastClose.After(lastOpen) {
+		action, at = "close", lastClose
+	}
+	if at.Unix() <= s.ScheduleLastRun {
+		return "", at
+	}
+	return action, at
+}
+
+func runGroupSchedules() {
+	if rdb == nil {
+		return
+	}
+	members, err := rdb.SMembers(ctx, groupSchedulesKey).Result()
+	if err != nil {
+		return
+	}
+
+	for _, m := range members {
+		parts := strings.SplitN(m, "|", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		botID, chatID := parts[0], parts[1]
+
+		client := getClientForBot(botID)
+		if client == nil {
+			continue // بوٹ آف لائن ہے، واپس آنے پر مس شدہ ٹرانزیشن لگ جائے گی
+		}
+
+		s := getGroupSettings(botID, chatID)
+		if s.ScheduleClose == "" || s.ScheduleOpen == "" {
+			rdb.SRem(ctx, groupSchedulesKey, m)
+			continue
+		}
+
+		action, at := dueScheduleAction(s, time.Now())
+		if action == "" {
+			continue
+		}
+
+		jid, err := types.ParseJID(chatID)
+		if err != nil {
+			continue
+		}
+		if err := applyScheduledTransition(client, jid, s, action); err != nil {
+			fmt.Printf("❌ [SCHEDULER] %s %s failed: %v\n", action, chatID, err)
+			continue
+		}
+
+		s.ScheduleLastRun = at.Unix()
+		saveGroupSettings(botID, s)
+		fmt.Printf("⏰ [SCHEDULER] Bot:%s | Group:%s | %s\n", botID, chatID, strings.ToUpper(action))
+	}
+}
+
+func applyScheduledTransition(client *whatsmeow.Client, chat types.JID, s *GroupSettings, action string) error {
+	closing := action == "close"
+	if err := client.SetGroupAnnounce(context.Background(), chat, closing); err != nil {
+		return err
+	}
+
+	var msg string
+	if closing {
+		msg = fmt.Sprintf(`╔════════════════╗
+║ 🌙 NIGHT MODE
+╠════════════════╣
+║ 🔒 Group closed
+║ ⏰ Opens at %s
+║ 🌍 %s
+╚════════════════╝`, s.ScheduleOpen, scheduleLocation(s).String())
+	} else {
+		msg = fmt.Sprintf(`╔════════════════╗
+║ ☀️ GOOD MORNING
+╠════════════════╣
+║ 🔓 Group opened
+║ ⏰ Closes at %s
+║ 🌍 %s
+╚════════════════╝`, s.ScheduleClose, scheduleLocation(s).String())
+	}
+
+	client.SendMessage(context.Background(), chat, &waProto.Message{
+		Conversation: proto.String(msg),
+	})
+	return nil
+}
+
+// ---------------------------------------------------------
+// ⚙️ .group schedule close 23:00 open 07:00 [tz Asia/Karachi]
+// ⚙️ .group schedule off  |  .group timezone <Area/City>
+// ---------------------------------------------------------
+func handleGroupSchedule(client *whatsmeow.Client, v *events.Message, args []string) {
+	botID := getCleanID(client.Store.ID.User)
+	chatID := v.Info.Chat.String()
+	s := getGroupSettings(botID, chatID)
+	member := botID + "|" + chatID
+
+	if len(args) == 0 {
+		status := "🔴 OFF"
+		if s.ScheduleClose != "" && s.ScheduleOpen != "" {
+			status = fmt.Sprintf("🟢 Close %s | Open %s", s.ScheduleClose, s.ScheduleOpen)
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ ⏰ SCHEDULE
+╠════════════════╣
+║ %s
+║ 🌍 %s
+╠════════════════╣
+║ .group schedule
+║  close 23:00
+║  open 07:00
+║  [tz Asia/Karachi]
+║ .group schedule off
+╚════════════════╝`, status, scheduleLocation(s).String())
+		replyMessage(client, v, msg)
+		return
+	}
+
+	if strings.ToLower(args[0]) == "off" {
+		s.ScheduleClose = ""
+		s.ScheduleOpen = ""
+		s.ScheduleLastRun = 0
+		saveGroupSettings(botID, s)
+		if rdb != nil {
+			rdb.SRem(ctx, groupSchedulesKey, member)
+		}
+		replyMessage(client, v, "❌ *Group Schedule:* OFF")
+		return
+	}
+
+	closeAt, openAt, tz := "", "", s.Timezone
+	for i := 0; i+1 < len(args); i += 2 {
+		switch strings.ToLower(args[i]) {
+		case "close":
+			closeAt = args[i+1]
+		case "open":
+			openAt = args[i+1]
+		case "tz", "timezone":
+			tz = args[i+1]
+		}
+	}
+
+	if _, _, ok := parseClock(closeAt); !ok {
+		replyMessage(client, v, "⚠️ Usage: .group schedule close 23:00 open 07:00 [tz Asia/Karachi]")
+		return
+	}
+	if _, _, ok := parseClock(openAt); !ok || openAt == closeAt {
+		replyMessage(client, v, "⚠️ Usage: .group schedule close 23:00 open 07:00 [tz Asia/Karachi]")
+		return
+	}
+	if tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			replyMessage(client, v, "❌ Invalid timezone. Example: Asia/Karachi")
+			return
+		}
+	}
+
+	s.ScheduleClose = closeAt
+	s.ScheduleOpen = openAt
+	s.Timezone = tz
+
+	// ابھی کون سا وقت چل رہا ہے؟ اگر رات کا ہے تو فوراً بند کر دیں
+	action, at := dueScheduleAction(&GroupSettings{
+		ScheduleClose: closeAt, ScheduleOpen: openAt, Timezone: tz,
+	}, time.Now())
+	now := "🔓 Open now"
+	if action == "close" {
+		if err := client.SetGroupAnnounce(context.Background(), v.Info.Chat, true); err != nil {
+			// ScheduleLastRun پرانا رہے تاکہ اگلے ٹک پر دوبارہ کوشش ہو
+			now = "⚠️ Close failed: " + explainGroupError(err) + "\n║ Retrying every 30s"
+		} else {
+			s.ScheduleLastRun = at.Unix()
+			now = "🔒 Closed now (night)"
+		}
+	} else {
+		s.ScheduleLastRun = at.Unix()
+	}
+
+	saveGroupSettings(botID, s)
+	if rdb != nil {
+		rdb.SAdd(ctx, groupSchedulesKey, member)
+	}
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ ✅ SCHEDULE SET
+╠════════════════╣
+║ 🌙 Close: %s
+║ ☀️ Open: %s
+║ 🌍 %s
+║ %s
+╚════════════════╝`, closeAt, openAt, scheduleLocation(s).String(), now)
+	replyMessage(client, v, msg)
+}
+
+func handleGroupTimezone(client *whatsmeow.Client, v *events.Message, args []string) {
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	if len(args) == 0 {
+		replyMessage(client, v, "🌍 Timezone: "+scheduleLocation(s).String()+"\n⚠️ Usage: .group timezone Asia/Karachi")
+		return
+	}
+	if _, err := time.LoadLocation(args[0]); err != nil {
+		replyMessage(client, v, "❌ Invalid timezone. Example: Asia/Karachi")
+		return
+	}
+
+	s.Timezone = args[0]
+	saveGroupSettings(botID, s)
+	replyMessage(client, v, "✅ *Timezone:* "+args[0])
+}
@@ -238,6 +238,7 @@ func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSet
 			replyMessage(client, v, "⚠️ Failed to Delete (Give me Admin Rights)")
 			return
 		}
+		logModAction(client, v.Info.Chat, types.EmptyJID, v.Info.Sender, "auto delete", reason)
 
 		// نوٹیفکیشن بھیجیں
 		msg := fmt.Sprintf(`╔════════════════╗
@@ -271,6 +272,7 @@ func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSet
 			replyMessage(client, v, "⚠️ Failed to Kick (Give me Admin Rights)")
 			return
 		}
+		logModAction(client, v.Info.Chat, types.EmptyJID, v.Info.Sender, "auto kick", reason)
 		
 		msg := fmt.Sprintf(`╔════════════════╗
 ║ 👢 KICKED
@@ -290,61 +292,9 @@ func takeSecurityAction(client *whatsmeow.Client, v *events.Message, s *GroupSet
 
 	case "deletewarn":
 		client.SendMessage(context.Background(), v.Info.Chat, client.BuildRevoke(v.Info.Chat, v.Info.Sender, v.Info.ID))
-
-		senderKey := v.Info.Sender.String()
-		if s.Warnings == nil {
-			s.Warnings = make(map[string]int)
-		}
-		s.Warnings[senderKey]++
-		warnCount := s.Warnings[senderKey]
-
-		if warnCount >= 3 {
-			_, err := client.UpdateGroupParticipants(context.Background(), v.Info.Chat,
-				[]types.JID{v.Info.Sender}, whatsmeow.ParticipantChangeRemove)
-			
-			if err != nil {
-				replyMessage(client, v, "⚠️ Failed to Kick (User has 3 warnings)")
-			} else {
-				delete(s.Warnings, senderKey)
-				
-				msg := fmt.Sprintf(`╔════════════════╗
-║ 🚫 KICKED
-╠════════════════╣
-║ User: @%s
-║ Warning: 3/3
-║ Reason: %s
-╚════════════════╝`, v.Info.Sender.User, reason)
-				
-				senderStr := v.Info.Sender.String()
-				client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
-					ExtendedTextMessage: &waProto.ExtendedTextMessage{
-						Text: proto.String(msg),
-						ContextInfo: &waProto.ContextInfo{MentionedJID: []string{senderStr}},
-					},
-				})
-			}
-		} else {
-			msg := fmt.Sprintf(`╔════════════════╗
-║ ⚠️ WARNING
-╠════════════════╣
-║ User: @%s
-║ Count: %d/3
-║ Reason: %s
-╚════════════════╝`, v.Info.Sender.User, warnCount, reason)
-			
-			senderStr := v.Info.Sender.String()
-			client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
-				ExtendedTextMessage: &waProto.ExtendedTextMessage{
-					Text: proto.String(msg),
-					ContextInfo: &waProto.ContextInfo{
-						MentionedJID: []string{senderStr},
-						StanzaID:     proto.String(v.Info.ID),
-						Participant:  proto.String(senderStr),
-					},
-				},
-			})
-		}
-		saveGroupSettings(botID, s)
+		// وہی کاؤنٹر جو .warn، رپورٹ اور ری ایکشن استعمال کرتے ہیں
+		logModAction(client, v.Info.Chat, types.EmptyJID, v.Info.Sender, "auto warn", reason)
+		applyWarning(client, v.Info.Chat, v.Info.Sender, reason, true)
 	}
 }
 
@@ -448,6 +398,7 @@ func startSecuritySetup(client *whatsmeow.Client, v *events.Message, args []stri
 		// باقی ٹائپس (antipic, antivideo) کے لیے یہاں کوڈ ایڈ کریں اگر وہ settings سٹرکچر میں ہیں
 		
 		saveGroupSettings(botID, settings)
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, secType+" off", "")
 		replyMessage(client, v, fmt.Sprintf("✅ %s has been DISABLED.", secType))
 		return
 	}
@@ -621,6 +572,7 @@ func handleSetupResponse(client *whatsmeow.Client, v *events.Message) {
 		
 		// سیشن ختم
 		delete(setupMap, quotedID) 
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, state.Type+" on", actionText)
 
 		adminBypass := "YES ✅"
 		if !s.AntilinkAdmin {
@@ -678,6 +630,19 @@ func handleGroupInfoChange(client *whatsmeow.Client, v *events.GroupInfo) {
 
 	// ✅ 2. اب botID پاس کریں
 	settings := getGroupSettings(botID, chatID)
+
+	// 🔐 نام/تفصیل لاک
+	handleLockInfoChange(client, v, settings)
+
+	// 🚫 بین شدہ یوزرز واپس آئیں تو نکال دیں
+	if len(v.Join) > 0 {
+		v.Join = kickBannedJoiners(client, v.JID, v.Join, settings)
+	}
+
+	// 📜 نئے ممبرز کو رولز DM (ویلکم آف ہو تب بھی)
+	if settings.RulesDM && settings.Rules != "" && len(v.Join) > 0 {
+		go sendRulesDM(client, v.JID, v.Join, settings)
+	}
 	
 	if !settings.Welcome { return }
 
@@ -702,22 +667,8 @@ func handleGroupInfoChange(client *whatsmeow.Client, v *events.GroupInfo) {
             userNum := strings.Split(left.User, "@")[0]
 
 			if sender.User == left.User {
-                // خود لیفٹ ہوا
-				msg := fmt.Sprintf(`╔════════════════╗
-║ 👋 GOODBYE
-╠════════════════╣
-║ 👤 User: @%s
-║ 📉 Status: Left
-╚════════════════╝`, userNum)
-
-				client.SendMessage(context.Background(), v.JID, &waProto.Message{
-					ExtendedTextMessage: &waProto.ExtendedTextMessage{
-						Text: proto.String(msg),
-						ContextInfo: &waProto.ContextInfo{
-							MentionedJID: []string{leftStr},
-						},
-					},
-				})
+                // خود لیفٹ ہوا (کسٹم گڈبائے ٹیمپلیٹ)
+				sendGroupGreeting(client, v.JID, left, settings, true)
 			} else {
                 // کک کیا گیا (By Admin)
 				msg := fmt.Sprintf(`╔════════════════╗
@@ -785,21 +736,7 @@ func handleGroupInfoChange(client *whatsmeow.Client, v *events.GroupInfo) {
 	// ✅ Join event (Welcome)
 	if v.Join != nil && len(v.Join) > 0 {
 		for _, joined := range v.Join {
-			msg := fmt.Sprintf(`╔════════════════╗
-║ 👋 WELCOME
-╠════════════════╣
-║ 👤 User: @%s
-║ 🎉 Enjoy here!
-╚════════════════╝`, joined.User)
-
-			client.SendMessage(context.Background(), v.JID, &waProto.Message{
-				ExtendedTextMessage: &waProto.ExtendedTextMessage{
-					Text: proto.String(msg),
-					ContextInfo: &waProto.ContextInfo{
-						MentionedJID: []string{joined.String()},
-					},
-				},
-			})
+			sendGroupGreeting(client, v.JID, joined, settings, false)
             time.Sleep(500 * time.Millisecond)
 		}
 	}
@@ -814,8 +751,21 @@ func handleGroupInfoChange(client *whatsmeow.Client, v *events.GroupInfo) {
 // 1. COMMAND: .antibug (Toggle ON/OFF)
 // ---------------------------------------------------------
 // یہ فنکشن اب ایرر نہیں دے گا کیونکہ یہ client اور message قبول کر رہا ہے
-func handleAntiBug(client *whatsmeow.Client, v *events.Message) {
-	AntiBugEnabled = !AntiBugEnabled
+// باقی آپشنز (status, set, action) antibug.go میں ہیں
+func handleAntiBug(client *whatsmeow.Client, v *events.Message, args []string) {
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "on":
+			AntiBugEnabled = true
+		case "off":
+			AntiBugEnabled = false
+		default:
+			handleAntiBugConfig(client, v, args)
+			return
+		}
+	} else {
+		AntiBugEnabled = !AntiBugEnabled
+	}
 	
 	status := "OFF ❌"
 	if AntiBugEnabled {
@@ -840,96 +790,6 @@ func extractText(m *waProto.Message) string {
 	return ""
 }
 
-// ---------------------------------------------------------
-// UPDATED: Aggressive Virus Scanner
-// ---------------------------------------------------------
-// ---------------------------------------------------------
-// 1. ADVANCED VIRUS SCANNER
-// ---------------------------------------------------------
-// ---------------------------------------------------------
-// 1. ADVANCED VIRUS SCANNER (Logic Based)
-// ---------------------------------------------------------
-func scanForVirus(msg string) bool {
-	// A. لمبائی چیک (Length Check)
-	if len(msg) > 4000 {
-		fmt.Println("⚠️ Message too long (Possible Crash Payload)")
-		return true
-	}
-
-	// B. خطرناک کریکٹرز (Dangerous Unicode)
-	dangerous := []string{
-		"\u202e", // Right-to-Left Override (Crash King)
-		"\u202d", // Left-to-Right Override
-		"\u202a", // LRE
-		"\u202b", // RLE
-		"\u200f", // RTL Mark
-		"\u200e", // LTR Mark
-	}
-
-	foundCount := 0
-	for _, char := range dangerous {
-		if strings.Contains(msg, char) {
-			foundCount++
-		}
-	}
-
-	// اگر ایک ہی میسج میں 3 سے زیادہ بار یہ نشانیاں ملیں
-	if foundCount >= 3 {
-		return true
-	}
-
-	// C. Repeater Check (Junk Flood)
-	if strings.Count(msg, "\u200b") > 10 { 
-		return true
-	}
-
-	return false
-}
-
-// ---------------------------------------------------------
-// 2. AUTO PROTECT ACTION (Fixed Build Errors)
-// ---------------------------------------------------------
-func AutoProtect(client *whatsmeow.Client, v *events.Message) bool {
-	// گروپ کو اگنور کریں
-	if v.Info.IsGroup {
-		return false
-	}
-
-	// ٹیکسٹ نکالیں
-	text := ""
-	if v.Message.GetConversation() != "" {
-		text = v.Message.GetConversation()
-	} else if v.Message.GetExtendedTextMessage() != nil {
-		text = v.Message.GetExtendedTextMessage().GetText()
-	}
-
-	if text == "" {
-		return false
-	}
-
-	// چیک کریں
-	if scanForVirus(text) {
-		sender := v.Info.Sender
-
-		fmt.Printf("🚨 VIRUS DETECTED from %s | ACTION: BLOCKING USER\n", sender.User)
-
-		// ✅ FIX: Assignment Mismatch Error Solved
-		// یہاں ہم نے _, err لگایا ہے تاکہ پہلا ویلیو اگنور ہو جائے اور صرف ایرر ملے
-		_, err := client.UpdateBlocklist(context.Background(), sender, events.BlocklistChangeActionBlock)
-		
-		if err != nil {
-			fmt.Println("❌ Block Failed:", err)
-		} else {
-			fmt.Println("✅ User Successfully Blocked to prevent crash.")
-		}
-		
-		return true
-	}
-
-	return false
-}
-
-
 var badChars = []string{
 	"\u200b", // Zero Width Space
 	"\u200c", // ZWNJ
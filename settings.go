@@ -49,6 +49,7 @@ func toggleAlwaysOnline(client *whatsmeow.Client, v *events.Message) {
 ║ ✅ Updated
 ╚════════════════╝`, status, statusText)
 
+	logModAction(client, types.EmptyJID, v.Info.Sender, types.EmptyJID, "alwaysonline", statusText)
 	replyMessage(client, v, msg)
 }
 
@@ -82,6 +83,7 @@ func toggleAutoRead(client *whatsmeow.Client, v *events.Message) {
 ║ ✅ Updated
 ╚════════════════╝`, status, statusText)
 
+	logModAction(client, types.EmptyJID, v.Info.Sender, types.EmptyJID, "autoread", statusText)
 	replyMessage(client, v, msg)
 }
 
@@ -140,6 +142,7 @@ func toggleAutoReact(client *whatsmeow.Client, v *events.Message) {
 		} else {
 			// اب آن کریں
 			data.AutoReact = true
+			logModAction(client, types.EmptyJID, v.Info.Sender, types.EmptyJID, "autoreact", "Enabled")
 			msg := `╔════════════════╗
 ║ ✅ SUCCESS
 ╠════════════════╣
@@ -161,6 +164,7 @@ func toggleAutoReact(client *whatsmeow.Client, v *events.Message) {
 		} else {
 			// اب آف کریں
 			data.AutoReact = false
+			logModAction(client, types.EmptyJID, v.Info.Sender, types.EmptyJID, "autoreact", "Disabled")
 			msg := `╔════════════════╗
 ║ 🛑 STOPPED
 ╠════════════════╣
@@ -232,6 +236,7 @@ func toggleAutoStatus(client *whatsmeow.Client, v *events.Message) {
 ║ 🔄 State: %s
 ║ ✅ Saved to DB
 ╚════════════════╝`, icon, state)
+	logModAction(client, types.EmptyJID, v.Info.Sender, types.EmptyJID, "autostatus", state)
 	replyMessage(client, v, msg)
 }
 
@@ -281,6 +286,7 @@ func toggleStatusReact(client *whatsmeow.Client, v *events.Message) {
 ║ 🔄 State: %s
 ║ ✅ Saved to DB
 ╚════════════════╝`, icon, state)
+	logModAction(client, types.EmptyJID, v.Info.Sender, types.EmptyJID, "statusreact", state)
 	replyMessage(client, v, msg)
 }
 
@@ -441,6 +447,7 @@ func handleSetPrefix(client *whatsmeow.Client, v *events.Message, args []string)
 ║ 💡 Ex: %smenu
 ╚════════════════╝`, newPrefix, newPrefix)
 
+	logModAction(client, types.EmptyJID, v.Info.Sender, types.EmptyJID, "setprefix", newPrefix)
 	replyMessage(client, v, msg)
 }
 
@@ -500,6 +507,9 @@ func handleMode(client *whatsmeow.Client, v *events.Message, args []string) {
 			replyMessage(client, v, msg)
 			return
 		}
+		if mode == "private" && !confirmAction(client, v, "mode private", "Turn the bot off in this group?") {
+			return
+		}
 
 		// ✅ FIX: Bot ID نکال کر Settings اپڈیٹ کریں
 		rawBotID := client.Store.ID.User
@@ -508,6 +518,7 @@ func handleMode(client *whatsmeow.Client, v *events.Message, args []string) {
 		s := getGroupSettings(botID, v.Info.Chat.String())
 		s.Mode = mode
 		saveGroupSettings(botID, s)
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "mode", mode)
 
 		var modeDesc string
 		switch mode {
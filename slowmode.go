@@ -0,0 +1,206 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 👣 MEMBER TRACKER
+// ہر گروپ میں ہر ممبر کا آخری میسج اور آخری نوٹس (RAM میں)
+// Shared by slow mode and other per-user moderation checks.
+
+type memberTrack struct {
+	LastMsg    time.Time
+	LastNotice map[string]time.Time // feature (slow/mute/filter) -> آخری نوٹس
+}
+
+// memberTrackTTL کے بعد پرانے ریکارڈ ہٹا دیے جاتے ہیں (سب سے لمبی ونڈو 1 گھنٹہ)
+const memberTrackTTL = 2 * time.Hour
+
+var (
+	memberTracks   = make(map[string]*memberTrack)
+	memberTrackMux sync.Mutex
+	memberPrunedAt time.Time
+)
+
+func memberTrackKey(chat, user string) string {
+	return chat + "|" + getCleanID(user)
+}
+
+// memberTrackFor ریکارڈ لاتا یا بناتا ہے؛ کال کرنے والا لاک رکھے
+func memberTrackFor(chat, user string) *memberTrack {
+	now := time.Now()
+	if now.Sub(memberPrunedAt) > 10*time.Minute {
+		for key, t := range memberTracks {
+			stale := now.Sub(t.LastMsg) > memberTrackTTL
+			for _, at := range t.LastNotice {
+				if now.Sub(at) <= memberTrackTTL {
+					stale = false
+				}
+			}
+			if stale {
+				delete(memberTracks, key)
+			}
+		}
+		memberPrunedAt = now
+	}
+
+	key := memberTrackKey(chat, user)
+	t, ok := memberTracks[key]
+	if !ok {
+		t = &memberTrack{LastNotice: make(map[string]time.Time)}
+		memberTracks[key] = t
+	}
+	return t
+}
+
+// lastMemberMsg آخری قبول شدہ میسج کا وقت (کچھ نہیں لکھتا)
+func lastMemberMsg(chat, user string) time.Time {
+	memberTrackMux.Lock()
+	defer memberTrackMux.Unlock()
+
+	if t, ok := memberTracks[memberTrackKey(chat, user)]; ok {
+		return t.LastMsg
+	}
+	return time.Time{}
+}
+
+// touchMember قبول شدہ میسج کا وقت لکھتا ہے
+func touchMember(chat, user string, now time.Time) {
+	memberTrackMux.Lock()
+	defer memberTrackMux.Unlock()
+
+	memberTrackFor(chat, user).LastMsg = now
+}
+
+// shouldNotifyMember نوٹس کی ریٹ لمٹ (ہر فیچر کی الگ، ہر یوزر کو interval میں ایک بار)
+func shouldNotifyMember(chat, user, feature string, interval time.Duration) bool {
+	memberTrackMux.Lock()
+	defer memberTrackMux.Unlock()
+
+	t := memberTrackFor(chat, user)
+	if time.Since(t.LastNotice[feature]) < interval {
+		return false
+	}
+	t.LastNotice[feature] = time.Now()
+	return true
+}
+
+// 🐢 SLOW MODE
+// handleSlowMode true واپس کرتا ہے اگر میسج ڈیلیٹ کر دیا گیا
+func handleSlowMode(client *whatsmeow.Client, v *events.Message) bool {
+	// revoke/edit جیسے پروٹوکول میسج ممبر کا نیا میسج نہیں
+	if !v.Info.IsGroup || v.Info.IsFromMe || v.Message.GetProtocolMessage() != nil {
+		return false
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	if s.SlowMode <= 0 || s.Mode == "private" {
+		return false
+	}
+
+	// وقت صرف قبول شدہ میسج پر لکھا جاتا ہے، ڈیلیٹ شدہ پر نہیں
+	chatID := v.Info.Chat.String()
+	prev := lastMemberMsg(chatID, v.Info.Sender.User)
+	window := time.Duration(s.SlowMode) * time.Second
+	wait := window - v.Info.Timestamp.Sub(prev)
+	if prev.IsZero() || wait <= 0 {
+		touchMember(chatID, v.Info.Sender.User, v.Info.Timestamp)
+		return false
+	}
+
+	// ایڈمن اور اونر مستثنیٰ
+	if isAdmin(client, v.Info.Chat, v.Info.Sender) || isOwner(client, v.Info.Sender) {
+		touchMember(chatID, v.Info.Sender.User, v.Info.Timestamp)
+		return false
+	}
+
+	_, err := client.SendMessage(context.Background(), v.Info.Chat, client.BuildRevoke(v.Info.Chat, v.Info.Sender, v.Info.ID))
+	if err != nil {
+		touchMember(chatID, v.Info.Sender.User, v.Info.Timestamp)
+		return false // بوٹ ایڈمن نہیں، میسج رہ گیا
+	}
+
+	notice := window
+	if notice < time.Minute {
+		notice = time.Minute
+	}
+	if shouldNotifyMember(chatID, v.Info.Sender.User, "slow", notice) {
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 🐢 SLOW MODE
+╠════════════════╣
+║ Your message was
+║ removed.
+║ ⏱️ 1 msg / %ds
+║ ⏳ Wait %ds
+╚════════════════╝`, s.SlowMode, int(wait.Seconds())+1)
+		client.SendMessage(context.Background(), v.Info.Sender.ToNonAD(), &waProto.Message{
+			Conversation: proto.String(msg),
+		})
+	}
+	return true
+}
+
+// ---------------------------------------------------------
+// ⚙️ COMMAND: .slowmode <seconds> | off
+// ---------------------------------------------------------
+func handleSlowModeCmd(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	if len(args) == 0 {
+		status := "🔴 OFF"
+		if s.SlowMode > 0 {
+			status = fmt.Sprintf("🟢 %ds", s.SlowMode)
+		}
+		replyMessage(client, v, fmt.Sprintf(`╔════════════════╗
+║ 🐢 SLOW MODE
+╠════════════════╣
+║ Status: %s
+╠════════════════╣
+║ .slowmode 30
+║ .slowmode off
+╚════════════════╝`, status))
+		return
+	}
+
+	arg := strings.ToLower(strings.TrimSuffix(args[0], "s"))
+	if arg == "off" || arg == "0" {
+		s.SlowMode = 0
+		saveGroupSettings(botID, s)
+		logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "slowmode", "off")
+		replyMessage(client, v, "❌ *Slow Mode:* OFF")
+		return
+	}
+
+	secs, err := strconv.Atoi(arg)
+	if err != nil || secs < 1 || secs > 3600 {
+		replyMessage(client, v, "⚠️ Usage: .slowmode <1-3600 seconds> | off")
+		return
+	}
+
+	s.SlowMode = secs
+	saveGroupSettings(botID, s)
+	logModAction(client, v.Info.Chat, v.Info.Sender, types.EmptyJID, "slowmode", fmt.Sprintf("%ds", secs))
+	replyMessage(client, v, fmt.Sprintf("✅ *Slow Mode:* ON\n⏱️ Members can send 1 message every %ds.\n👮 Admins are exempt.", secs))
+}
@@ -0,0 +1,372 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 📊 GROUP ACTIVITY STATS
+// Only counters are kept, never message contents:
+//   gstats:<bot>:<chat>:<yyyymmdd>   hash  "<jid>|text|media|sticker" -> count
+//   group_lastseen:<bot>:<chat>      hash  user -> unix (last message)
+// Daily buckets expire after statsRetentionDays. The last-seen hash expires
+// the same way once a group goes quiet.
+
+const statsRetentionDays = 90
+
+func statsDayKey(botID, chatID string, day time.Time) string {
+	return "gstats:" + botID + ":" + chatID + ":" + day.UTC().Format("20060102")
+}
+
+// 👁️ LAST SEEN (per group, per member)
+func lastSeenKey(botID, chatID string) string {
+	return "group_lastseen:" + botID + ":" + chatID
+}
+
+func lastSeenSinceKey(botID, chatID string) string {
+	return "group_lastseen_since:" + botID + ":" + chatID
+}
+
+// messageStatKind میسج کی قسم (text / media / sticker)
+func messageStatKind(m *waProto.Message) string {
+	switch {
+	case m.GetStickerMessage() != nil:
+		return "sticker"
+	case m.GetImageMessage() != nil, m.GetVideoMessage() != nil, m.GetAudioMessage() != nil,
+		m.GetDocumentMessage() != nil, m.GetPtvMessage() != nil:
+		return "media"
+	case m.GetConversation() != "", m.GetExtendedTextMessage() != nil:
+		return "text"
+	}
+	return ""
+}
+
+// recordGroupActivity کاؤنٹرز اور last seen اپڈیٹ کرتا ہے
+func recordGroupActivity(botID string, v *events.Message) {
+	if rdb == nil || !v.Info.IsGroup || v.Info.IsFromMe {
+		return
+	}
+	kind := messageStatKind(v.Message)
+	if kind == "" {
+		return // ری ایکشن، پول ووٹ، پروٹوکول میسجز
+	}
+
+	chatID := v.Info.Chat.String()
+	user := v.Info.Sender.ToNonAD().String()
+	retention := statsRetentionDays * 24 * time.Hour
+
+	dayKey := statsDayKey(botID, chatID, v.Info.Timestamp)
+	seenKey := lastSeenKey(botID, chatID)
+
+	pipe := rdb.Pipeline()
+	pipe.HIncrBy(ctx, dayKey, user+"|"+kind, 1)
+	pipe.Expire(ctx, dayKey, retention+24*time.Hour)
+	pipe.HSet(ctx, seenKey, getCleanID(v.Info.Sender.User), v.Info.Timestamp.Unix())
+	pipe.Expire(ctx, seenKey, retention)
+	pipe.SetNX(ctx, lastSeenSinceKey(botID, chatID), time.Now().Unix(), 0)
+	pipe.Expire(ctx, lastSeenSinceKey(botID, chatID), retention)
+	pipe.Exec(ctx)
+}
+
+// loadLastSeen پورے گروپ کا ڈیٹا اور ٹریکنگ شروع ہونے کا وقت
+func loadLastSeen(botID, chatID string) (map[string]int64, time.Time) {
+	seen := make(map[string]int64)
+	if rdb == nil {
+		return seen, time.Time{}
+	}
+	raw, _ := rdb.HGetAll(ctx, lastSeenKey(botID, chatID)).Result()
+	for u, ts := range raw {
+		if n, err := strconv.ParseInt(ts, 10, 64); err == nil {
+			seen[u] = n
+		}
+	}
+	since := time.Time{}
+	if n, err := rdb.Get(ctx, lastSeenSinceKey(botID, chatID)).Int64(); err == nil {
+		since = time.Unix(n, 0)
+	}
+	return seen, since
+}
+
+type memberStats struct {
+	JID    string
+	Counts map[string]int64
+	Total  int64
+}
+
+// loadGroupStats پچھلے N دنوں کے کاؤنٹرز جمع کرتا ہے
+func loadGroupStats(botID, chatID string, days int) map[string]*memberStats {
+	out := make(map[string]*memberStats)
+	if rdb == nil {
+		return out
+	}
+
+	now := time.Now()
+	for i := 0; i < days; i++ {
+		raw, err := rdb.HGetAll(ctx, statsDayKey(botID, chatID, now.AddDate(0, 0, -i))).Result()
+		if err != nil {
+			continue
+		}
+		for field, val := range raw {
+			idx := strings.LastIndex(field, "|")
+			if idx < 0 {
+				continue
+			}
+			n, _ := strconv.ParseInt(val, 10, 64)
+			jid, kind := field[:idx], field[idx+1:]
+
+			m, ok := out[jid]
+			if !ok {
+				m = &memberStats{JID: jid, Counts: make(map[string]int64)}
+				out[jid] = m
+			}
+			m.Counts[kind] += n
+			m.Total += n
+		}
+	}
+	return out
+}
+
+// parseStatsPeriod "today" / "7d" / "30d" → دن
+func parseStatsPeriod(args []string, def int) (int, string) {
+	if len(args) == 0 {
+		return def, fmt.Sprintf("%dd", def)
+	}
+	switch a := strings.ToLower(args[0]); a {
+	case "today", "day", "1d":
+		return 1, "Today"
+	case "week":
+		return 7, "7d"
+	case "month":
+		return 30, "30d"
+	default:
+		if n, ok := parseDays(a); ok {
+			if n > statsRetentionDays {
+				n = statsRetentionDays
+			}
+			return n, fmt.Sprintf("%dd", n)
+		}
+	}
+	return def, fmt.Sprintf("%dd", def)
+}
+
+func sortedStats(stats map[string]*memberStats) []*memberStats {
+	list := make([]*memberStats, 0, len(stats))
+	for _, m := range stats {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Total == list[j].Total {
+			return list[i].JID < list[j].JID
+		}
+		return list[i].Total > list[j].Total
+	})
+	return list
+}
+
+func sendMentionReply(client *whatsmeow.Client, v *events.Message, text string, mentions []string) {
+	client.SendMessage(context.Background(), v.Info.Chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text: proto.String(text),
+			ContextInfo: &waProto.ContextInfo{
+				MentionedJID:  mentions,
+				StanzaID:      proto.String(v.Info.ID),
+				Participant:   proto.String(v.Info.Sender.String()),
+				QuotedMessage: v.Message,
+			},
+		},
+	})
+}
+
+// ---------------------------------------------------------
+// 🏆 COMMAND: .topactive [today|7d|30d]
+// ---------------------------------------------------------
+func handleTopActive(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	days, label := parseStatsPeriod(args, 7)
+	list := sortedStats(loadGroupStats(botID, v.Info.Chat.String(), days))
+	if len(list) == 0 {
+		replyMessage(client, v, "📊 No activity recorded for this period yet.")
+		return
+	}
+	if len(list) > 10 {
+		list = list[:10]
+	}
+
+	medals := []string{"🥇", "🥈", "🥉"}
+	var sb strings.Builder
+	var mentions []string
+	for i, m := range list {
+		rank := fmt.Sprintf("%d.", i+1)
+		if i < len(medals) {
+			rank = medals[i]
+		}
+		user := strings.Split(m.JID, "@")[0]
+		sb.WriteString(fmt.Sprintf("║ %s @%s — %d\n║    💬%d 🖼️%d 🎭%d\n", rank, user, m.Total,
+			m.Counts["text"], m.Counts["media"], m.Counts["sticker"]))
+		mentions = append(mentions, m.JID)
+	}
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 🏆 TOP ACTIVE (%s)
+╠════════════════╣
+%s╚════════════════╝`, label, sb.String())
+	sendMentionReply(client, v, msg, mentions)
+}
+
+// ---------------------------------------------------------
+// 📈 COMMAND: .mystats
+// ---------------------------------------------------------
+func handleMyStats(client *whatsmeow.Client, v *events.Message) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	chatID := v.Info.Chat.String()
+	me := v.Info.Sender.ToNonAD().String()
+
+	period := func(days int) (*memberStats, int) {
+		list := sortedStats(loadGroupStats(botID, chatID, days))
+		for i, m := range list {
+			if m.JID == me {
+				return m, i + 1
+			}
+		}
+		return &memberStats{Counts: map[string]int64{}}, 0
+	}
+
+	today, _ := period(1)
+	week, _ := period(7)
+	month, rank := period(30)
+
+	last := "Never"
+	seen, _ := loadLastSeen(botID, chatID)
+	if ts := seen[getCleanID(v.Info.Sender.User)]; ts > 0 {
+		last = time.Unix(ts, 0).Format("02 Jan 15:04")
+	}
+	rankStr := "-"
+	if rank > 0 {
+		rankStr = "#" + strconv.Itoa(rank)
+	}
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 📈 MY STATS
+╠════════════════╣
+║ 👤 @%s
+║ 📅 Today: %d
+║ 🗓️ 7 days: %d
+║ 📆 30 days: %d
+║    💬%d 🖼️%d 🎭%d
+║ 🏅 Rank (30d): %s
+║ 🕒 Last: %s
+╚════════════════╝`, v.Info.Sender.User, today.Total, week.Total, month.Total,
+		month.Counts["text"], month.Counts["media"], month.Counts["sticker"], rankStr, last)
+	sendMentionReply(client, v, msg, []string{me})
+}
+
+// ---------------------------------------------------------
+// 💤 COMMAND: .inactive [days]
+// ---------------------------------------------------------
+func handleInactive(client *whatsmeow.Client, v *events.Message, args []string) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+
+	days := 7
+	if len(args) > 0 {
+		n, ok := parseDays(args[0])
+		if !ok {
+			replyMessage(client, v, "⚠️ Usage: .inactive [days]")
+			return
+		}
+		days = n
+	}
+
+	info, err := client.GetGroupInfo(context.Background(), v.Info.Chat)
+	if err != nil {
+		replyMessage(client, v, "❌ Failed to fetch group info.")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	seen, since := loadLastSeen(botID, v.Info.Chat.String())
+	cutoff := time.Now().AddDate(0, 0, -days)
+
+	var lines []string
+	var mentions []string
+	for _, p := range info.Participants {
+		if isBotParticipant(client, p) {
+			continue
+		}
+		var last int64
+		for _, id := range participantIDs(p) {
+			if seen[id] > last {
+				last = seen[id]
+			}
+		}
+		if last > 0 && time.Unix(last, 0).After(cutoff) {
+			continue
+		}
+
+		when := "never"
+		if last > 0 {
+			when = fmt.Sprintf("%dd ago", int(time.Since(time.Unix(last, 0)).Hours()/24))
+		}
+		lines = append(lines, fmt.Sprintf("@%s (%s)", p.JID.User, when))
+		mentions = append(mentions, p.JID.String())
+	}
+
+	if len(lines) == 0 {
+		replyMessage(client, v, fmt.Sprintf("✅ Everyone was active in the last %d days.", days))
+		return
+	}
+
+	total := len(lines)
+	more := ""
+	if len(lines) > 50 {
+		more = fmt.Sprintf("\n║ ...and %d more", len(lines)-50)
+		lines = lines[:50]
+		mentions = mentions[:50]
+	}
+	note := ""
+	if since.IsZero() || since.After(cutoff) {
+		note = "\n║ ⚠️ Tracking started " + sinceLabel(since)
+	}
+
+	msg := fmt.Sprintf(`╔════════════════╗
+║ 💤 INACTIVE %dd
+╠════════════════╣
+║ %s%s
+╠════════════════╣
+║ 👥 %d members%s
+║ 🧹 .kick inactive %dd
+╚════════════════╝`, days, strings.Join(lines, "\n║ "), more, total, note, days)
+	sendMentionReply(client, v, msg, mentions)
+}
+
+func sinceLabel(since time.Time) string {
+	if since.IsZero() {
+		return "just now"
+	}
+	return since.Format("02 Jan 2006")
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 📣 TAG HELPERS (.tagall / .hidetag)
+// Large groups are mentioned in chunks so a single message never carries
+// hundreds of mentions; a per-group cooldown stops repeated mass pings.
+
+const (
+	tagChunkSize = 100
+	tagChunkGap  = 1500 * time.Millisecond
+	tagCooldown  = 2 * time.Minute
+)
+
+// ⏳ گروپ لیول کول ڈاؤن (ہر ایکشن کے لیے الگ)
+// Entries hold the expiry time and are only written after the action has
+// actually gone out, so a failed send never burns the cooldown.
+var (
+	groupCooldowns       = make(map[string]time.Time)
+	groupCooldownMux     sync.Mutex
+	groupCooldownSweptAt time.Time
+)
+
+// groupCooldownLeft صرف باقی وقت بتاتا ہے؛ کامیابی کے بعد markGroupCooldown کال کریں
+func groupCooldownLeft(chat types.JID, action string) time.Duration {
+	groupCooldownMux.Lock()
+	defer groupCooldownMux.Unlock()
+
+	if left := time.Until(groupCooldowns[chat.String()+"|"+action]); left > 0 {
+		return left
+	}
+	return 0
+}
+
+// markGroupCooldown ایکشن کامیاب ہونے پر کول ڈاؤن شروع کرتا ہے؛ ختم شدہ کیز ہر 10 منٹ بعد صاف
+func markGroupCooldown(chat types.JID, action string, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	groupCooldownMux.Lock()
+	defer groupCooldownMux.Unlock()
+
+	now := time.Now()
+	if now.Sub(groupCooldownSweptAt) > 10*time.Minute {
+		for key, until := range groupCooldowns {
+			if now.After(until) {
+				delete(groupCooldowns, key)
+			}
+		}
+		groupCooldownSweptAt = now
+	}
+	groupCooldowns[chat.String()+"|"+action] = now.Add(d)
+}
+
+// parseTagFilter پہلا لفظ admins/members ہو تو فلٹر، باقی میسج
+func parseTagFilter(args []string) (string, []string) {
+	if len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case "admins", "admin":
+			return "admins", args[1:]
+		case "members", "member":
+			return "members", args[1:]
+		}
+	}
+	return "all", args
+}
+
+func filterTagTargets(participants []types.GroupParticipant, filter string) []types.JID {
+	var out []types.JID
+	for _, p := range participants {
+		admin := p.IsAdmin || p.IsSuperAdmin
+		if (filter == "admins" && !admin) || (filter == "members" && admin) {
+			continue
+		}
+		out = append(out, p.JID)
+	}
+	return out
+}
+
+func chunkJIDs(jids []types.JID, size int) [][]types.JID {
+	var chunks [][]types.JID
+	for len(jids) > size {
+		chunks = append(chunks, jids[:size])
+		jids = jids[size:]
+	}
+	if len(jids) > 0 {
+		chunks = append(chunks, jids)
+	}
+	return chunks
+}
+
+// tagContext مینشنز کے ساتھ کوٹ: ریپلائی ہو تو وہ اعلان، ورنہ (اگر دیا ہو) کمانڈ میسج
+func tagContext(v *events.Message, mentions []string, quoteCommand bool) *waProto.ContextInfo {
+	info := &waProto.ContextInfo{MentionedJID: mentions}
+	if ci := getContextInfo(v.Message); ci != nil && ci.QuotedMessage != nil && ci.GetStanzaID() != "" {
+		info.StanzaID = proto.String(ci.GetStanzaID())
+		info.Participant = proto.String(ci.GetParticipant())
+		info.QuotedMessage = ci.QuotedMessage
+	} else if quoteCommand {
+		info.StanzaID = proto.String(v.Info.ID)
+		info.Participant = proto.String(v.Info.Sender.String())
+		info.QuotedMessage = v.Message
+	}
+	return info
+}
+
+func jidStrings(jids []types.JID) []string {
+	out := make([]string, len(jids))
+	for i, j := range jids {
+		out[i] = j.String()
+	}
+	return out
+}
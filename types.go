@@ -23,6 +23,53 @@ type GroupSettings struct {
 	AntiSticker    bool           `bson:"antisticker" json:"antisticker"`
 	Warnings       map[string]int `bson:"warnings" json:"warnings"`
 	Welcome        bool   `json:"welcome"`
+
+	// 👋 Custom welcome/goodbye (placeholders: {user} {group} {desc} {count} {rules})
+	WelcomeText  string       `bson:"welcome_text" json:"welcome_text"`
+	GoodbyeText  string       `bson:"goodbye_text" json:"goodbye_text"`
+	WelcomeImage string       `bson:"welcome_image" json:"welcome_image"` // "" | icon | custom
+	WelcomeMedia *StoredMedia `bson:"welcome_media" json:"welcome_media,omitempty"`
+	Rules        string       `bson:"rules" json:"rules"`
+	RulesDM      bool         `bson:"rules_dm" json:"rules_dm"` // نئے ممبرز کو رولز DM میں
+
+	// ⏰ Night mode schedule ("HH:MM" in Timezone)
+	ScheduleClose   string `bson:"schedule_close" json:"schedule_close"`
+	ScheduleOpen    string `bson:"schedule_open" json:"schedule_open"`
+	Timezone        string `bson:"timezone" json:"timezone"`
+	ScheduleLastRun int64  `bson:"schedule_last_run" json:"schedule_last_run"` // آخری لاگو ٹرانزیشن (unix)
+
+	// 🔐 Lock info (approved snapshot, icon bytes in lockinfo_icon:<bot>:<chat>)
+	LockInfo      bool     `bson:"lock_info" json:"lock_info"`
+	LockName      string   `bson:"lock_name" json:"lock_name"`
+	LockTopic     string   `bson:"lock_topic" json:"lock_topic"`
+	LockIcon      bool     `bson:"lock_icon" json:"lock_icon"`
+	LockDemote    bool     `bson:"lock_demote" json:"lock_demote"`
+	TrustedAdmins []string `bson:"trusted_admins" json:"trusted_admins"`
+
+	ReportGroup string `bson:"report_group" json:"report_group"` // "" = ایڈمنز کے DM
+	SlowMode    int    `bson:"slow_mode" json:"slow_mode"`       // سیکنڈز، 0 = آف
+
+	// 🛂 Join requests / bans
+	Banned      []string `bson:"banned" json:"banned"`
+	JoinPolicy  bool     `bson:"join_policy" json:"join_policy"`
+	JoinAllow   []string `bson:"join_allow" json:"join_allow"` // country codes, e.g. +92
+	JoinDeny    []string `bson:"join_deny" json:"join_deny"`
+	JoinCaptcha bool     `bson:"join_captcha" json:"join_captcha"`
+
+	// 🎖️ Roles / mute
+	Roles       map[string][]string `bson:"roles" json:"roles"`               // role -> commands
+	MemberRoles map[string]string   `bson:"member_roles" json:"member_roles"` // user -> role
+	Muted       map[string]int64    `bson:"muted" json:"muted"`               // user -> expiry (0 = forever)
+
+	// 🚫 Disabled commands
+	DisabledCmds []string `bson:"disabled_cmds" json:"disabled_cmds"`
+	DisabledCats []string `bson:"disabled_cats" json:"disabled_cats"`
+
+	Network string `bson:"network" json:"network"` // <bot>:<name>, "" = not linked
+
+	// 👆 Reaction moderation (emoji -> delete|warn|kick, nil = defaults)
+	ReactMod     bool              `bson:"react_mod" json:"react_mod"`
+	ReactActions map[string]string `bson:"react_actions" json:"react_actions"`
 }
 // ✅ نام کو TikTokState سے بدل کر TTState کر دیا گیا ہے
 type TTState struct {
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.mau.fi/whatsmeow"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+)
+
+// ↩️ UNDO
+// .undo reverses the sender's most recent reversible action in this group,
+// found in the moderation log. Each undo is logged as "undo <action>", so
+// repeated .undo walks further back instead of undoing the same thing twice.
+
+const undoWindow = 15 * time.Minute
+
+var undoableActions = map[string]bool{
+	"kick": true, "promote": true, "demote": true, "mute": true, "warn": true, "ban": true,
+}
+
+// findUndoTarget بھیجنے والے کا آخری قابلِ واپسی ایکشن
+func findUndoTarget(entries []ModLogEntry, actor string) *ModLogEntry {
+	cutoff := time.Now().Add(-undoWindow).Unix()
+	pending := 0
+	for i := range entries {
+		e := &entries[i]
+		if e.Time < cutoff {
+			break
+		}
+		if shortJID(e.Actor) != actor {
+			continue
+		}
+		if strings.HasPrefix(e.Action, "undo ") {
+			pending++
+			continue
+		}
+		if !undoableActions[e.Action] || e.Target == "" {
+			continue
+		}
+		if pending > 0 {
+			pending--
+			continue
+		}
+		return e
+	}
+	return nil
+}
+
+// warnAutoKicked اس وارن کے بعد 3/3 پر بوٹ کی لاگ کی ہوئی کک (entries تازہ ترین پہلے)
+func warnAutoKicked(entries []ModLogEntry, warn *ModLogEntry) bool {
+	for i := range entries {
+		e := &entries[i]
+		if e == warn {
+			return false
+		}
+		if e.Actor == "bot" && e.Action == "kick" && e.Target == warn.Target && e.Time >= warn.Time {
+			return true
+		}
+	}
+	return false
+}
+
+func handleUndo(client *whatsmeow.Client, v *events.Message) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !hasGroupPermission(client, v, "undo") {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	chat := v.Info.Chat
+	entries := loadModLog(botID, chat.String(), modLogMax)
+
+	e := findUndoTarget(entries, getCleanID(v.Info.Sender.User))
+	if e == nil && !v.Info.SenderAlt.IsEmpty() {
+		e = findUndoTarget(entries, getCleanID(v.Info.SenderAlt.User))
+	}
+	if e == nil {
+		replyMessage(client, v, fmt.Sprintf("⚠️ Nothing to undo (only your actions from the last %d minutes).", int(undoWindow.Minutes())))
+		return
+	}
+
+	target, err := types.ParseJID(e.Target)
+	if err != nil {
+		replyMessage(client, v, "❌ Could not read the target of that action.")
+		return
+	}
+
+	var result string
+	switch e.Action {
+	case "kick":
+		result, err = undoKick(client, chat, target)
+
+	case "promote":
+		_, err = client.UpdateGroupParticipants(context.Background(), chat, []types.JID{target}, whatsmeow.ParticipantChangeDemote)
+		result = "Demoted again"
+
+	case "demote":
+		_, err = client.UpdateGroupParticipants(context.Background(), chat, []types.JID{target}, whatsmeow.ParticipantChangePromote)
+		result = "Promoted again"
+
+	case "mute":
+		s := getGroupSettings(botID, chat.String())
+		muted := cloneMap(s.Muted)
+		delete(muted, getCleanID(target.User))
+		s.Muted = muted
+		saveGroupSettings(botID, s)
+		setNetworkMute(s, target, 0, false)
+		result = "Unmuted"
+
+	case "warn":
+		s := getGroupSettings(botID, chat.String())
+		// 3/3 پر کک ہو چکا تو واپس ایڈ کریں اور گنتی 2/3 پر
+		if warnAutoKicked(entries, e) {
+			var added string
+			if added, err = undoKick(client, chat, target); err == nil {
+				warnings := cloneMap(s.Warnings)
+				warnings[e.Target] = 2
+				s.Warnings = warnings
+				saveGroupSettings(botID, s)
+				result = "Warning removed (2/3), " + strings.ToLower(added)
+			}
+			break
+		}
+		if s.Warnings[e.Target] > 0 {
+			warnings := cloneMap(s.Warnings)
+			warnings[e.Target]--
+			if warnings[e.Target] == 0 {
+				delete(warnings, e.Target)
+			}
+			s.Warnings = warnings
+			saveGroupSettings(botID, s)
+		}
+		result = fmt.Sprintf("Warning removed (%d/3)", s.Warnings[e.Target])
+
+	case "ban":
+		s := getGroupSettings(botID, chat.String())
+		id := getCleanID(target.User)
+		kept := make([]string, 0, len(s.Banned))
+		for _, b := range s.Banned {
+			if b != id {
+				kept = append(kept, b)
+			}
+		}
+		s.Banned = kept
+		saveGroupSettings(botID, s)
+		setNetworkBan(client, s, target, false)
+		// بین کے وقت گروپ میں نہیں تھا تو واپس ایڈ نہ کریں
+		if e.Reason == banNotInGroup {
+			result = "Unbanned"
+			break
+		}
+		var added string
+		added, err = undoKick(client, chat, target)
+		result = "Unbanned, " + strings.ToLower(added)
+	}
+
+	if err != nil {
+		groupResult(client, v, "UNDO "+strings.ToUpper(e.Action), "", err)
+		return
+	}
+
+	logModAction(client, chat, v.Info.Sender, target, "undo "+e.Action, "")
+	sendGroupNotice(client, chat, target, fmt.Sprintf(`╔════════════════╗
+║ ↩️ UNDO %s
+╠════════════════╣
+║ 👤 @%s
+║ ✅ %s
+╚════════════════╝`, strings.ToUpper(e.Action), target.User, result))
+}
+
+// undoKick دوبارہ ایڈ، پرائیویسی روکے تو انوائٹ
+func undoKick(client *whatsmeow.Client, chat, target types.JID) (string, error) {
+	res, err := client.UpdateGroupParticipants(context.Background(), chat, []types.JID{target}, whatsmeow.ParticipantChangeAdd)
+	if err != nil {
+		return "", err
+	}
+	if len(res) > 0 && res[0].Error == 403 {
+		if err := sendAddInvite(client, chat, res[0]); err != nil {
+			return "", err
+		}
+		return "Invite sent", nil
+	}
+	if len(res) > 0 && res[0].Error != 0 {
+		return "", fmt.Errorf("re-add failed (%s)", addErrorReason(res[0].Error))
+	}
+	return "Added back", nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"go.mau.fi/whatsmeow/types"
+)
+
+// 🧪 .undo must find the entries that groupAction and .warn really write.
+
+var (
+	undoTestChat   = types.NewJID("120363000000000001", types.GroupServer)
+	undoTestAdmin  = types.NewJID("923001112233", types.DefaultUserServer)
+	undoTestTarget = types.NewJID("923004445566", types.DefaultUserServer)
+)
+
+func TestFindUndoTargetGroupActions(t *testing.T) {
+	for action, ga := range groupActions {
+		t.Run(action, func(t *testing.T) {
+			entries := []ModLogEntry{
+				newModLogEntry("bot", undoTestChat, undoTestAdmin, undoTestTarget, ga.Log, ""),
+			}
+			e := findUndoTarget(entries, getCleanID(undoTestAdmin.User))
+			if e == nil {
+				t.Fatalf("%q logged as %q is not undoable", action, ga.Log)
+			}
+			if e.Target != undoTestTarget.String() {
+				t.Errorf("target = %q, want %q", e.Target, undoTestTarget.String())
+			}
+		})
+	}
+}
+
+func TestFindUndoTargetSkipsUndone(t *testing.T) {
+	// تازہ ترین پہلے: kick کو undo کیا جا چکا، اب promote کی باری
+	entries := []ModLogEntry{
+		newModLogEntry("bot", undoTestChat, undoTestAdmin, undoTestTarget, "undo kick", ""),
+		newModLogEntry("bot", undoTestChat, undoTestAdmin, undoTestTarget, groupActions["remove"].Log, ""),
+		newModLogEntry("bot", undoTestChat, undoTestAdmin, undoTestTarget, groupActions["promote"].Log, ""),
+	}
+	e := findUndoTarget(entries, getCleanID(undoTestAdmin.User))
+	if e == nil || e.Action != "promote" {
+		t.Fatalf("got %+v, want the promote entry", e)
+	}
+}
+
+func TestWarnAutoKicked(t *testing.T) {
+	warn := newModLogEntry("bot", undoTestChat, undoTestAdmin, undoTestTarget, "warn", "spam")
+	kick := newModLogEntry("bot", undoTestChat, types.EmptyJID, undoTestTarget, "kick", "3/3 warnings")
+
+	entries := []ModLogEntry{kick, warn}
+	if e := findUndoTarget(entries, getCleanID(undoTestAdmin.User)); e == nil || !warnAutoKicked(entries, e) {
+		t.Error("warn followed by the bot's 3/3 kick was not detected")
+	}
+
+	entries = []ModLogEntry{warn}
+	if warnAutoKicked(entries, &entries[0]) {
+		t.Error("plain warn reported as auto-kicked")
+	}
+}
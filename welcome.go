@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mau.fi/whatsmeow"
+	waProto "go.mau.fi/whatsmeow/binary/proto"
+	"go.mau.fi/whatsmeow/types"
+	"go.mau.fi/whatsmeow/types/events"
+	"google.golang.org/protobuf/proto"
+)
+
+// 👋 CUSTOM WELCOME / GOODBYE
+// Templates live in GroupSettings next to the Welcome toggle.
+// Placeholders: {user} {group} {desc} {count} {rules}
+
+const defaultWelcomeText = `╔════════════════╗
+║ 👋 WELCOME
+╠════════════════╣
+║ 👤 User: {user}
+║ 🎉 Enjoy here!
+╚════════════════╝`
+
+const defaultGoodbyeText = `╔════════════════╗
+║ 👋 GOODBYE
+╠════════════════╣
+║ 👤 User: {user}
+║ 📉 Status: Left
+╚════════════════╝`
+
+// renderGroupTemplate پلیس ہولڈرز کو اصل ویلیوز سے بدلتا ہے
+func renderGroupTemplate(tpl string, user types.JID, info *types.GroupInfo, s *GroupSettings) string {
+	group, desc, count := "", "", 0
+	if info != nil {
+		group = info.Name
+		desc = info.Topic
+		count = len(info.Participants)
+	}
+	rules := s.Rules
+	if rules == "" {
+		rules = "No rules set"
+	}
+
+	return strings.NewReplacer(
+		"{user}", "@"+user.User,
+		"{group}", group,
+		"{desc}", desc,
+		"{count}", strconv.Itoa(count),
+		"{rules}", rules,
+	).Replace(tpl)
+}
+
+// sendGroupGreeting ویلکم یا گڈبائے میسج بھیجتا ہے (ٹیمپلیٹ + تصویر)
+func sendGroupGreeting(client *whatsmeow.Client, chat, user types.JID, s *GroupSettings, goodbye bool) {
+	info, _ := client.GetGroupInfo(context.Background(), chat)
+
+	tpl := s.WelcomeText
+	if tpl == "" {
+		tpl = defaultWelcomeText
+	}
+	if goodbye {
+		tpl = s.GoodbyeText
+		if tpl == "" {
+			tpl = defaultGoodbyeText
+		}
+	}
+
+	text := renderGroupTemplate(tpl, user, info, s)
+	ci := &waProto.ContextInfo{MentionedJID: []string{user.String()}}
+
+	if !goodbye {
+		var media *StoredMedia
+		switch s.WelcomeImage {
+		case "custom":
+			media = s.WelcomeMedia
+		case "icon":
+			if icon, err := fetchGroupIcon(client, chat); err == nil {
+				media = icon
+			} else {
+				fmt.Printf("⚠️ [WELCOME] Group icon unavailable: %v\n", err)
+			}
+		}
+		if media != nil {
+			_, err := client.SendMessage(context.Background(), chat, media.toMessage(text, ci))
+			if err == nil {
+				return
+			}
+			fmt.Printf("⚠️ [WELCOME] Image send failed, falling back to text: %v\n", err)
+		}
+	}
+
+	client.SendMessage(context.Background(), chat, &waProto.Message{
+		ExtendedTextMessage: &waProto.ExtendedTextMessage{
+			Text:        proto.String(text),
+			ContextInfo: ci,
+		},
+	})
+}
+
+// quotedText ریپلائی کیے گئے میسج کا ٹیکسٹ
+func quotedText(v *events.Message) string {
+	ci := getContextInfo(v.Message)
+	if ci == nil || ci.QuotedMessage == nil {
+		return ""
+	}
+	return getText(ci.QuotedMessage)
+}
+
+// ---------------------------------------------------------
+// ⚙️ COMMANDS: .setwelcome / .setgoodbye / .testwelcome
+// ---------------------------------------------------------
+func handleSetGreeting(client *whatsmeow.Client, v *events.Message, args []string, goodbye bool) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+
+	name, cmdName := "WELCOME", "setwelcome"
+	current := s.WelcomeText
+	if goodbye {
+		name, cmdName = "GOODBYE", "setgoodbye"
+		current = s.GoodbyeText
+	}
+
+	sub := ""
+	if len(args) > 0 {
+		sub = strings.ToLower(args[0])
+	}
+
+	// 🖼️ .setwelcome image icon|off  (یا تصویر پر ریپلائی)
+	if sub == "image" && !goodbye {
+		mode := ""
+		if len(args) > 1 {
+			mode = strings.ToLower(args[1])
+		}
+		switch mode {
+		case "icon":
+			s.WelcomeImage = "icon"
+			replyMessage(client, v, "✅ *Welcome Image:* Group Icon")
+		case "off":
+			s.WelcomeImage = ""
+			s.WelcomeMedia = nil
+			replyMessage(client, v, "❌ *Welcome Image:* OFF")
+		default:
+			ci := getContextInfo(v.Message)
+			if ci == nil || ci.QuotedMessage == nil || ci.QuotedMessage.ImageMessage == nil {
+				replyMessage(client, v, "⚠️ Usage: .setwelcome image icon | off\n(or reply to an image with .setwelcome image)")
+				return
+			}
+			media, err := captureMedia(client, ci.QuotedMessage)
+			if err != nil {
+				replyMessage(client, v, "❌ Failed to save image: "+err.Error())
+				return
+			}
+			s.WelcomeImage = "custom"
+			s.WelcomeMedia = media
+			replyMessage(client, v, "✅ *Welcome Image:* Custom")
+		}
+		saveGroupSettings(botID, s)
+		return
+	}
+
+	if sub == "reset" {
+		if goodbye {
+			s.GoodbyeText = ""
+		} else {
+			s.WelcomeText = ""
+		}
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, fmt.Sprintf("✅ *%s* reset to default", name))
+		return
+	}
+
+	text := getCommandBody(v)
+	if text == "" {
+		text = quotedText(v)
+	}
+
+	// اگر ریپلائی تصویر پر ہے تو وہی تصویر بھی ویلکم کے ساتھ لگ جائے
+	imageSaved := false
+	if !goodbye {
+		if ci := getContextInfo(v.Message); ci != nil && ci.QuotedMessage != nil && ci.QuotedMessage.ImageMessage != nil {
+			if media, err := captureMedia(client, ci.QuotedMessage); err == nil {
+				s.WelcomeImage = "custom"
+				s.WelcomeMedia = media
+				imageSaved = true
+			}
+		}
+	}
+
+	if text == "" && imageSaved {
+		saveGroupSettings(botID, s)
+		replyMessage(client, v, "✅ *Welcome Image:* Custom")
+		return
+	}
+
+	if text == "" {
+		if current == "" {
+			current = "(default)"
+		}
+		msg := fmt.Sprintf(`╔════════════════╗
+║ 👋 %s TEMPLATE
+╠════════════════╣
+║ %s
+╠════════════════╣
+║ Usage: .%s <text>
+║ .%s reset
+║ Placeholders:
+║ {user} {group} {desc}
+║ {count} {rules}
+╚════════════════╝`, name, current, cmdName, cmdName)
+		replyMessage(client, v, msg)
+		return
+	}
+
+	if goodbye {
+		s.GoodbyeText = text
+	} else {
+		s.WelcomeText = text
+	}
+	saveGroupSettings(botID, s)
+
+	status := ""
+	if !s.Welcome {
+		status = "\n⚠️ Welcome messages are OFF. Use .welcome on"
+	}
+	replyMessage(client, v, fmt.Sprintf("✅ *%s Template Saved*\nUse .testwelcome to preview.%s", name, status))
+}
+
+func handleTestWelcome(client *whatsmeow.Client, v *events.Message, goodbye bool) {
+	if !v.Info.IsGroup {
+		replyMessage(client, v, "❌ This command is for Groups only.")
+		return
+	}
+	if !isAdmin(client, v.Info.Chat, v.Info.Sender) && !isOwner(client, v.Info.Sender) {
+		replyMessage(client, v, "❌ Only Admins!")
+		return
+	}
+
+	botID := getCleanID(client.Store.ID.User)
+	s := getGroupSettings(botID, v.Info.Chat.String())
+	sendGroupGreeting(client, v.Info.Chat, v.Info.Sender.ToNonAD(), s, goodbye)
+}